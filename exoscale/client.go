@@ -1,14 +1,22 @@
 package exoscale
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/exoscale/egoscale"
 	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
 	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 )
 
@@ -20,11 +28,32 @@ const (
 	defaultEnvironment     = "api"
 	defaultTimeout         = 5 * time.Minute
 	defaultGzipUserData    = true
+	defaultScopedKeyMaxAge = 3 * time.Hour
+
+	// scopedKeyNamePrefix is the prefix of the names of the API keys minted
+	// via the "scoped_key" provider setting, used to identify orphaned keys.
+	scopedKeyNamePrefix = "terraform-provider-exoscale-scoped-"
+
+	// scopedKeyRevokeTimeout bounds the revocation of the minted API keys
+	// when the plugin stops: Terraform (go-plugin) kills the plugin process
+	// about 2 seconds after closing its connection to it.
+	scopedKeyRevokeTimeout = time.Second
+
+	// defaultZone is the zone whose API endpoint is used to manage global
+	// (i.e. non-zoned) resources using the v2 API.
+	defaultZone = "ch-gva-2"
 )
 
 // userAgent represents the User Agent to advertise in outgoing HTTP requests.
 var userAgent string
 
+// scopedKeys holds the API keys minted by the provider during the run, along
+// with the client to use to revoke them once the plugin stops serving.
+var scopedKeys = struct {
+	sync.Mutex
+	keys map[string]*egoscale.Client
+}{keys: make(map[string]*egoscale.Client)}
+
 // BaseConfig represents the provider structure
 type BaseConfig struct {
 	key             string
@@ -34,15 +63,23 @@ type BaseConfig struct {
 	dnsEndpoint     string
 	environment     string
 	gzipUserData    bool
+	userAgentExtra  string
 	computeClient   *egoscale.Client
 	dnsClient       *egoscale.Client
+
+	tolerateUnavailableServices bool
 }
 
 func getClient(endpoint string, meta interface{}) *egoscale.Client {
 	config := meta.(BaseConfig)
 
+	ua := userAgent
+	if config.userAgentExtra != "" {
+		ua = fmt.Sprintf("%s %s", ua, config.userAgentExtra)
+	}
+
 	httpClient := cleanhttp.DefaultPooledClient()
-	httpClient.Transport = &defaultTransport{next: httpClient.Transport}
+	httpClient.Transport = &defaultTransport{next: httpClient.Transport, userAgent: ua}
 	if logging.IsDebugOrHigher() {
 		httpClient.Transport = logging.NewTransport(
 			"exoscale",
@@ -70,18 +107,13 @@ func getClient(endpoint string, meta interface{}) *egoscale.Client {
 		exov2.ClientOptWithTimeout(config.timeout),
 		exov2.ClientOptWithHTTPClient(func() *http.Client {
 			hc := cleanhttp.DefaultPooledClient()
-			hc.Transport = &defaultTransport{next: hc.Transport}
+			hc.Transport = &defaultTransport{next: hc.Transport, userAgent: ua}
 			if logging.IsDebugOrHigher() {
 				hc.Transport = logging.NewTransport("exoscale", hc.Transport)
 			}
 			return hc
 		}()),
-		exov2.ClientOptCond(func() bool {
-			if v := os.Getenv("EXOSCALE_TRACE"); v != "" {
-				return true
-			}
-			return false
-		}, exov2.ClientOptWithTrace()))
+		exov2.ClientOptCond(traceEnabled, exov2.ClientOptWithTrace()))
 	if err != nil {
 		panic(fmt.Sprintf("unable to initialize Exoscale API V2 client: %v", err))
 	}
@@ -90,6 +122,18 @@ func getClient(endpoint string, meta interface{}) *egoscale.Client {
 	return client
 }
 
+// traceEnabled reports whether API requests tracing is enabled, using the
+// first non-empty variable of providerTraceEnvVars.
+func traceEnabled() bool {
+	for _, envVar := range providerTraceEnvVars {
+		if v := os.Getenv(envVar); v != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
 // GetComputeClient builds a CloudStack client
 func GetComputeClient(meta interface{}) *egoscale.Client {
 	config := meta.(BaseConfig)
@@ -116,13 +160,36 @@ func getEnvironment(meta interface{}) string {
 	return config.environment
 }
 
+// unavailableServiceWarning returns a warning diagnostic if err reports that
+// the API service is not available in the current environment (i.e. the API
+// returned a "not found" error for a list operation) and the provider is
+// configured to tolerate unavailable services, or nil otherwise.
+func unavailableServiceWarning(meta interface{}, service string, err error) diag.Diagnostics {
+	config := meta.(BaseConfig)
+	if !config.tolerateUnavailableServices || !errors.Is(err, exoapi.ErrNotFound) {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("%s API not available", service),
+		Detail: fmt.Sprintf(
+			"The %s API is not available in the %q environment, returning an empty result "+
+				"(tolerate_unavailable_services is enabled).",
+			service,
+			getEnvironment(meta),
+		),
+	}}
+}
+
 type defaultTransport struct {
-	next http.RoundTripper
+	next      http.RoundTripper
+	userAgent string
 }
 
 // RoundTrip executes a single HTTP transaction while augmenting requests with custom headers.
 func (t *defaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	req.Header.Add("User-Agent", userAgent)
+	req.Header.Add("User-Agent", t.userAgent)
 
 	resp, err := t.next.RoundTrip(req)
 	if err != nil {
@@ -131,3 +198,125 @@ func (t *defaultTransport) RoundTrip(req *http.Request) (*http.Response, error)
 
 	return resp, nil
 }
+
+// scopedKeyName returns the name of an API key minted at time t, made of the
+// scopedKeyNamePrefix, the optional user-provided name and the minting Unix
+// timestamp (e.g. "terraform-provider-exoscale-scoped-ci-1630000000").
+func scopedKeyName(name string, t time.Time) string {
+	if name != "" {
+		return fmt.Sprintf("%s%s-%d", scopedKeyNamePrefix, name, t.Unix())
+	}
+
+	return fmt.Sprintf("%s%d", scopedKeyNamePrefix, t.Unix())
+}
+
+// scopedKeyMintedAt returns the time an API key named name was minted at, and
+// false if the name doesn't match the format returned by scopedKeyName.
+func scopedKeyMintedAt(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, scopedKeyNamePrefix) {
+		return time.Time{}, false
+	}
+
+	ts, err := strconv.ParseInt(name[strings.LastIndex(name, "-")+1:], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(ts, 0), true
+}
+
+// expiredScopedKeys returns the API keys among keys minted by the provider
+// more than maxAge before now.
+func expiredScopedKeys(keys []egoscale.APIKey, maxAge time.Duration, now time.Time) []string {
+	expired := make([]string, 0)
+	for _, key := range keys {
+		if mintedAt, ok := scopedKeyMintedAt(key.Name); ok && now.Sub(mintedAt) > maxAge {
+			expired = append(expired, key.Key)
+		}
+	}
+
+	return expired
+}
+
+// revokeExpiredScopedKeys revokes the API keys minted by the provider more
+// than maxAge ago, which have been left behind by provider plugins that were
+// terminated without revoking their key. Failures are only logged, as they
+// shouldn't prevent the provider from being configured.
+func revokeExpiredScopedKeys(ctx context.Context, config BaseConfig, maxAge time.Duration) {
+	client := GetComputeClient(config)
+
+	resp, err := client.RequestWithContext(ctx, &egoscale.ListAPIKeys{})
+	if err != nil {
+		log.Printf("[WARN] unable to list API keys to revoke expired scoped API keys: %s", err)
+		return
+	}
+
+	for _, key := range expiredScopedKeys(resp.(*egoscale.ListAPIKeysResponse).APIKeys, maxAge, time.Now()) {
+		if _, err := client.RequestWithContext(ctx, &egoscale.RevokeAPIKey{Key: key}); err != nil {
+			log.Printf("[WARN] unable to revoke expired scoped API key %s: %s", key, err)
+			continue
+		}
+		log.Printf("[DEBUG] revoked expired scoped API key %s", key)
+	}
+}
+
+// mintScopedKey creates a restricted API key allowed to perform the specified
+// operations on the specified resources, using the credentials of the provided
+// configuration. The key is registered for revocation by RevokeScopedKeys.
+func mintScopedKey(
+	ctx context.Context,
+	config BaseConfig,
+	name string,
+	operations, resources []string,
+) (*egoscale.APIKey, error) {
+	client := GetComputeClient(config)
+
+	resp, err := client.RequestWithContext(ctx, &egoscale.CreateAPIKey{
+		Name:       scopedKeyName(name, time.Now()),
+		Operations: strings.Join(operations, ","),
+		Resources:  strings.Join(resources, ","),
+	})
+	if err != nil {
+		return nil, err
+	}
+	apiKey := resp.(*egoscale.APIKey)
+
+	scopedKeys.Lock()
+	scopedKeys.keys[apiKey.Key] = client
+	scopedKeys.Unlock()
+
+	log.Printf("[DEBUG] minted scoped API key %s (%s)", apiKey.Key, apiKey.Name)
+
+	return apiKey, nil
+}
+
+// RevokeScopedKeys revokes the API keys minted by the provider during the run.
+// It is meant to be called once the plugin has stopped serving requests. The
+// keys are revoked concurrently, within scopedKeyRevokeTimeout so that it
+// fits in the grace period before the plugin process is killed. Revocation is
+// best effort: keys that couldn't be revoked are left to
+// revokeExpiredScopedKeys.
+func RevokeScopedKeys() {
+	scopedKeys.Lock()
+	defer scopedKeys.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), scopedKeyRevokeTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for key, client := range scopedKeys.keys {
+		wg.Add(1)
+		go func(key string, client *egoscale.Client) {
+			defer wg.Done()
+
+			if _, err := client.RequestWithContext(ctx, &egoscale.RevokeAPIKey{Key: key}); err != nil {
+				log.Printf("[ERROR] unable to revoke scoped API key %s: %s", key, err)
+				return
+			}
+			log.Printf("[DEBUG] revoked scoped API key %s", key)
+		}(key, client)
+
+		delete(scopedKeys.keys, key)
+	}
+	wg.Wait()
+}
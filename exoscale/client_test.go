@@ -1,8 +1,14 @@
 package exoscale
 
 import (
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/exoscale/egoscale"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/stretchr/testify/require"
 )
 
@@ -21,3 +27,49 @@ func Test_getClient(t *testing.T) {
 	require.Equal(t, testConfig.timeout, client.Timeout)
 	require.IsType(t, &defaultTransport{}, client.HTTPClient.Transport)
 }
+
+func Test_unavailableServiceWarning(t *testing.T) {
+	var (
+		notFoundErr = fmt.Errorf("unable to list: %w", exoapi.ErrNotFound)
+		otherErr    = errors.New("boom")
+		tolerant    = BaseConfig{tolerateUnavailableServices: true}
+	)
+
+	require.Nil(t, unavailableServiceWarning(BaseConfig{}, "SKS", notFoundErr))
+	require.Nil(t, unavailableServiceWarning(tolerant, "SKS", otherErr))
+
+	diags := unavailableServiceWarning(tolerant, "SKS", notFoundErr)
+	require.Len(t, diags, 1)
+	require.Equal(t, diag.Warning, diags[0].Severity)
+}
+
+func Test_scopedKeyName(t *testing.T) {
+	now := time.Unix(1630000000, 0)
+
+	require.Equal(t, "terraform-provider-exoscale-scoped-1630000000", scopedKeyName("", now))
+	require.Equal(t, "terraform-provider-exoscale-scoped-ci-1630000000", scopedKeyName("ci", now))
+
+	for _, name := range []string{"", "ci", "my-ci"} {
+		mintedAt, ok := scopedKeyMintedAt(scopedKeyName(name, now))
+		require.True(t, ok, name)
+		require.Equal(t, now, mintedAt, name)
+	}
+
+	for _, name := range []string{"terraform-provider-exoscale-1630000000", "terraform-provider-exoscale-scoped-ci", "ci"} {
+		_, ok := scopedKeyMintedAt(name)
+		require.False(t, ok, name)
+	}
+}
+
+func Test_expiredScopedKeys(t *testing.T) {
+	now := time.Unix(1630000000, 0)
+
+	keys := []egoscale.APIKey{
+		{Key: "EXOexpired", Name: scopedKeyName("", now.Add(-25*time.Hour))},
+		{Key: "EXOrecent", Name: scopedKeyName("ci", now.Add(-time.Hour))},
+		{Key: "EXOother", Name: "ci-1500000000"},
+	}
+
+	require.Equal(t, []string{"EXOexpired"}, expiredScopedKeys(keys, defaultScopedKeyMaxAge, now))
+	require.Equal(t, []string{"EXOexpired", "EXOrecent"}, expiredScopedKeys(keys, 30*time.Minute, now))
+}
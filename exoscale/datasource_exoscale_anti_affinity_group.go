@@ -0,0 +1,94 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	dsAntiAffinityGroupAttrDescription = "description"
+	dsAntiAffinityGroupAttrID          = "id"
+	dsAntiAffinityGroupAttrInstances   = "instances"
+	dsAntiAffinityGroupAttrName        = "name"
+)
+
+func dataSourceAntiAffinityGroup() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			dsAntiAffinityGroupAttrDescription: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			dsAntiAffinityGroupAttrID: {
+				Type:          schema.TypeString,
+				Description:   "ID of the Anti-Affinity Group",
+				Optional:      true,
+				ConflictsWith: []string{dsAntiAffinityGroupAttrName},
+			},
+			dsAntiAffinityGroupAttrInstances: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Set:      schema.HashString,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			dsAntiAffinityGroupAttrName: {
+				Type:          schema.TypeString,
+				Description:   "Name of the Anti-Affinity Group",
+				Optional:      true,
+				ConflictsWith: []string{dsAntiAffinityGroupAttrID},
+			},
+		},
+
+		ReadContext: dataSourceAntiAffinityGroupRead,
+	}
+}
+
+func dataSourceAntiAffinityGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), defaultZone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	v, byID := d.GetOk(dsAntiAffinityGroupAttrID)
+	if !byID {
+		var byName bool
+		if v, byName = d.GetOk(dsAntiAffinityGroupAttrName); !byName {
+			return diag.FromErr(errors.New("either name or id must be specified"))
+		}
+	}
+
+	antiAffinityGroup, err := client.FindAntiAffinityGroup(ctx, defaultZone, v.(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(*antiAffinityGroup.ID)
+
+	instances, err := antiAffinityGroupInstanceIDs(ctx, client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsAntiAffinityGroupAttrID, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsAntiAffinityGroupAttrDescription, defaultString(antiAffinityGroup.Description, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsAntiAffinityGroupAttrInstances, instances); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsAntiAffinityGroupAttrName, defaultString(antiAffinityGroup.Name, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
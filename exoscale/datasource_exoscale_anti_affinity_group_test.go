@@ -0,0 +1,92 @@
+package exoscale
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var (
+	testAccDataSourceAntiAffinityGroupName        = acctest.RandomWithPrefix(testPrefix)
+	testAccDataSourceAntiAffinityGroupDescription = acctest.RandString(10)
+
+	testAccDataSourceAntiAffinityGroupResourceConfig = fmt.Sprintf(`
+resource "exoscale_anti_affinity_group" "test" {
+  name = "%s"
+  description = "%s"
+}
+
+resource "exoscale_compute_instance" "test" {
+  zone = "%s"
+  name = "%s"
+  type = "standard.tiny"
+  template_id = "%s"
+  anti_affinity_group_ids = [exoscale_anti_affinity_group.test.id]
+}`,
+		testAccDataSourceAntiAffinityGroupName,
+		testAccDataSourceAntiAffinityGroupDescription,
+		testZoneName,
+		testAccDataSourceAntiAffinityGroupName,
+		testInstanceTemplateID,
+	)
+)
+
+func TestAccDataSourceAntiAffinityGroup(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "exoscale_anti_affinity_group" "test" {}`,
+				ExpectError: regexp.MustCompile("either name or id must be specified"),
+			},
+			{
+				Config: fmt.Sprintf(`%s
+data "exoscale_anti_affinity_group" "by-id" {
+  id = exoscale_anti_affinity_group.test.id
+  depends_on = [exoscale_compute_instance.test]
+}`,
+					testAccDataSourceAntiAffinityGroupResourceConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceAntiAffinityGroupAttributes("data.exoscale_anti_affinity_group.by-id", testAttrs{
+						dsAntiAffinityGroupAttrDescription:      validateString(testAccDataSourceAntiAffinityGroupDescription),
+						dsAntiAffinityGroupAttrID:               validation.ToDiagFunc(validation.IsUUID),
+						dsAntiAffinityGroupAttrInstances + ".#": validateString("1"),
+						dsAntiAffinityGroupAttrName:             validateString(testAccDataSourceAntiAffinityGroupName),
+					}),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`%s
+data "exoscale_anti_affinity_group" "by-name" {
+  name = exoscale_anti_affinity_group.test.name
+}`,
+					testAccDataSourceAntiAffinityGroupResourceConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceAntiAffinityGroupAttributes("data.exoscale_anti_affinity_group.by-name", testAttrs{
+						dsAntiAffinityGroupAttrID:   validation.ToDiagFunc(validation.IsUUID),
+						dsAntiAffinityGroupAttrName: validateString(testAccDataSourceAntiAffinityGroupName),
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAntiAffinityGroupAttributes(r string, expected testAttrs) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ds, ok := s.RootModule().Resources[r]
+		if !ok {
+			return errors.New("data source not found in the state")
+		}
+
+		return checkResourceAttributes(expected, ds.Primary.Attributes)
+	}
+}
@@ -84,6 +84,16 @@ func dataSourceCompute() *schema.Resource {
 				Computed:    true,
 				Description: "Compute instance public ipv6 address (if ipv6 is enabled)",
 			},
+			"manager_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the Compute instance manager, if the instance is managed",
+			},
+			"manager_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the Compute instance manager (e.g. Instance Pool), if the instance is managed",
+			},
 			"private_network_ip_addresses": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -198,6 +208,17 @@ func dataSourceComputeApply(d *schema.ResourceData, instance *egoscale.VirtualMa
 		return err
 	}
 
+	if err := d.Set("manager_type", instance.Manager); err != nil {
+		return err
+	}
+	var managerID string
+	if instance.ManagerID != nil {
+		managerID = instance.ManagerID.String()
+	}
+	if err := d.Set("manager_id", managerID); err != nil {
+		return err
+	}
+
 	privateNetworkIPs := make([]string, 0)
 	for _, nic := range instance.Nic {
 		if nic.IsDefault {
@@ -0,0 +1,250 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	dsComputeInstanceListAttrIDs                       = "ids"
+	dsComputeInstanceListAttrInstances                 = "instances"
+	dsComputeInstanceListAttrInstanceID                = "id"
+	dsComputeInstanceListAttrInstanceIPv6Address       = "ipv6_address"
+	dsComputeInstanceListAttrInstanceLabels            = "labels"
+	dsComputeInstanceListAttrInstanceName              = "name"
+	dsComputeInstanceListAttrInstancePrivateNetworkIDs = "private_network_ids"
+	dsComputeInstanceListAttrInstancePublicIPAddress   = "public_ip_address"
+	dsComputeInstanceListAttrInstanceState             = "state"
+	dsComputeInstanceListAttrInstanceType              = "type"
+	dsComputeInstanceListAttrLabels                    = "labels"
+	dsComputeInstanceListAttrNameRegex                 = "name_regex"
+	dsComputeInstanceListAttrType                      = "type"
+	dsComputeInstanceListAttrZone                      = "zone"
+)
+
+func dataSourceComputeInstanceList() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			dsComputeInstanceListAttrIDs: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			dsComputeInstanceListAttrInstances: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						dsComputeInstanceListAttrInstanceID: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsComputeInstanceListAttrInstanceIPv6Address: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsComputeInstanceListAttrInstanceLabels: {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						dsComputeInstanceListAttrInstanceName: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsComputeInstanceListAttrInstancePrivateNetworkIDs: {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						dsComputeInstanceListAttrInstancePublicIPAddress: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsComputeInstanceListAttrInstanceState: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsComputeInstanceListAttrInstanceType: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			dsComputeInstanceListAttrLabels: {
+				Type:        schema.TypeMap,
+				Description: "Labels the Compute instances must all have to be selected",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			dsComputeInstanceListAttrNameRegex: {
+				Type:         schema.TypeString,
+				Description:  "Regular expression the name of the Compute instances must match to be selected",
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			dsComputeInstanceListAttrType: {
+				Type:        schema.TypeString,
+				Description: "Type of the Compute instances to select (format: FAMILY.SIZE)",
+				Optional:    true,
+			},
+			dsComputeInstanceListAttrZone: {
+				Type:        schema.TypeString,
+				Description: "Zone of the Compute instances",
+				Required:    true,
+			},
+		},
+
+		ReadContext: dataSourceComputeInstanceListRead,
+	}
+}
+
+func dataSourceComputeInstanceListRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	zone := d.Get(dsComputeInstanceListAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	filter := computeInstanceListFilter{
+		labels: make(map[string]string),
+		typ:    strings.ToLower(d.Get(dsComputeInstanceListAttrType).(string)),
+	}
+	for k, v := range d.Get(dsComputeInstanceListAttrLabels).(map[string]interface{}) {
+		filter.labels[k] = v.(string)
+	}
+	if v := d.Get(dsComputeInstanceListAttrNameRegex).(string); v != "" {
+		filter.nameRegex = regexp.MustCompile(v)
+	}
+
+	var diags diag.Diagnostics
+
+	instances, err := client.ListInstances(ctx, zone)
+	if err != nil {
+		warning := unavailableServiceWarning(meta, "Compute", err)
+		if warning == nil {
+			return diag.FromErr(fmt.Errorf("unable to list Compute instances: %w", err))
+		}
+		diags = append(diags, warning...)
+	}
+
+	instanceTypeNames := make(map[string]string)
+	if len(instances) > 0 {
+		instanceTypes, err := client.ListInstanceTypes(ctx, zone)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("unable to list instance types: %w", err))
+		}
+		for _, instanceType := range instanceTypes {
+			instanceTypeNames[*instanceType.ID] = instanceTypeName(instanceType)
+		}
+	}
+
+	selected := make(map[string]map[string]interface{})
+	for _, instance := range instances {
+		typ := instanceTypeNames[defaultString(instance.InstanceTypeID, "")]
+		if !filter.match(instance, typ) {
+			continue
+		}
+
+		labels := make(map[string]string)
+		if instance.Labels != nil {
+			labels = *instance.Labels
+		}
+
+		privateNetworkIDs := make([]string, 0)
+		if instance.PrivateNetworkIDs != nil {
+			privateNetworkIDs = *instance.PrivateNetworkIDs
+		}
+
+		var publicIPAddress, ipv6Address string
+		if instance.PublicIPAddress != nil {
+			publicIPAddress = instance.PublicIPAddress.String()
+		}
+		if instance.IPv6Address != nil {
+			ipv6Address = instance.IPv6Address.String()
+		}
+
+		selected[*instance.ID] = map[string]interface{}{
+			dsComputeInstanceListAttrInstanceID:                *instance.ID,
+			dsComputeInstanceListAttrInstanceIPv6Address:       ipv6Address,
+			dsComputeInstanceListAttrInstanceLabels:            labels,
+			dsComputeInstanceListAttrInstanceName:              defaultString(instance.Name, ""),
+			dsComputeInstanceListAttrInstancePrivateNetworkIDs: privateNetworkIDs,
+			dsComputeInstanceListAttrInstancePublicIPAddress:   publicIPAddress,
+			dsComputeInstanceListAttrInstanceState:             defaultString(instance.State, ""),
+			dsComputeInstanceListAttrInstanceType:              typ,
+		}
+	}
+
+	ids := make([]string, 0, len(selected))
+	for id := range selected {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	list := make([]map[string]interface{}, len(ids))
+	for i, id := range ids {
+		list[i] = selected[id]
+	}
+
+	d.SetId(fmt.Sprintf(
+		"%s/%s/%s/%s",
+		zone,
+		labelsSelectorString(filter.labels),
+		d.Get(dsComputeInstanceListAttrNameRegex).(string),
+		filter.typ,
+	))
+
+	if err := d.Set(dsComputeInstanceListAttrIDs, ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsComputeInstanceListAttrInstances, list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// computeInstanceListFilter represents the criteria a Compute instance must
+// all satisfy to be selected by the exoscale_compute_instance_list data
+// source. Unset criteria match any Compute instance.
+type computeInstanceListFilter struct {
+	labels    map[string]string
+	nameRegex *regexp.Regexp
+	typ       string
+}
+
+// match returns true if the Compute instance of type typ ("FAMILY.SIZE")
+// satisfies the filter criteria.
+func (f computeInstanceListFilter) match(instance *exov2.Instance, typ string) bool {
+	var labels map[string]string
+	if instance.Labels != nil {
+		labels = *instance.Labels
+	}
+	if !labelsMatch(labels, f.labels) {
+		return false
+	}
+
+	if f.nameRegex != nil && !f.nameRegex.MatchString(defaultString(instance.Name, "")) {
+		return false
+	}
+
+	if f.typ != "" && f.typ != typ {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,121 @@
+package exoscale
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	exov2 "github.com/exoscale/egoscale/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var (
+	testAccDataSourceComputeInstanceListInstanceName = acctest.RandomWithPrefix(testPrefix)
+	testAccDataSourceComputeInstanceListLabelValue   = acctest.RandomWithPrefix(testPrefix)
+
+	testAccDataSourceComputeInstanceListResourceConfig = fmt.Sprintf(`
+locals {
+  zone = "%s"
+}
+
+resource "exoscale_compute_instance" "test" {
+  zone = local.zone
+  name = "%s"
+  type = "standard.tiny"
+  template_id = "%s"
+  disk_size = 10
+  labels = {
+    test = "%s"
+  }
+
+  timeouts {
+    delete = "10m"
+  }
+}`,
+		testZoneName,
+		testAccDataSourceComputeInstanceListInstanceName,
+		testInstanceTemplateID,
+		testAccDataSourceComputeInstanceListLabelValue,
+	)
+)
+
+func TestComputeInstanceListFilter(t *testing.T) {
+	var (
+		name   = "web-1"
+		labels = map[string]string{"role": "web"}
+	)
+	instance := &exov2.Instance{Name: &name, Labels: &labels}
+
+	for _, tt := range []struct {
+		filter   computeInstanceListFilter
+		expected bool
+	}{
+		{filter: computeInstanceListFilter{}, expected: true},
+		{filter: computeInstanceListFilter{labels: map[string]string{"role": "web"}}, expected: true},
+		{filter: computeInstanceListFilter{labels: map[string]string{"role": "db"}}, expected: false},
+		{filter: computeInstanceListFilter{nameRegex: regexp.MustCompile("^web-")}, expected: true},
+		{filter: computeInstanceListFilter{nameRegex: regexp.MustCompile("^db-")}, expected: false},
+		{filter: computeInstanceListFilter{typ: "standard.tiny"}, expected: true},
+		{filter: computeInstanceListFilter{typ: "standard.small"}, expected: false},
+	} {
+		if actual := tt.filter.match(instance, "standard.tiny"); actual != tt.expected {
+			t.Errorf("bad match for filter %+v, wanted %t, got %t", tt.filter, tt.expected, actual)
+		}
+	}
+}
+
+func TestAccDataSourceComputeInstanceList(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`%s
+data "exoscale_compute_instance_list" "match" {
+  zone = local.zone
+  type = exoscale_compute_instance.test.type
+  labels = exoscale_compute_instance.test.labels
+}`,
+					testAccDataSourceComputeInstanceListResourceConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceComputeInstanceListAttributes("data.exoscale_compute_instance_list.match", testAttrs{
+						dsComputeInstanceListAttrIDs + ".#":                         validateString("1"),
+						dsComputeInstanceListAttrIDs + ".0":                         validation.ToDiagFunc(validation.IsUUID),
+						dsComputeInstanceListAttrInstances + ".0.name":              validateString(testAccDataSourceComputeInstanceListInstanceName),
+						dsComputeInstanceListAttrInstances + ".0.type":              validateString("standard.tiny"),
+						dsComputeInstanceListAttrInstances + ".0.state":             validateString("running"),
+						dsComputeInstanceListAttrInstances + ".0.public_ip_address": validation.ToDiagFunc(validation.IsIPv4Address),
+					}),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`%s
+data "exoscale_compute_instance_list" "no-match" {
+  zone = local.zone
+  name_regex = "^${exoscale_compute_instance.test.name}-nope$"
+}`,
+					testAccDataSourceComputeInstanceListResourceConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceComputeInstanceListAttributes("data.exoscale_compute_instance_list.no-match", testAttrs{
+						dsComputeInstanceListAttrIDs + ".#": validateString("0"),
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceComputeInstanceListAttributes(r string, expected testAttrs) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ds, ok := s.RootModule().Resources[r]
+		if !ok {
+			return errors.New("data source not found in the state")
+		}
+
+		return checkResourceAttributes(expected, ds.Primary.Attributes)
+	}
+}
@@ -29,6 +29,8 @@ var (
 		"id":                             validation.ToDiagFunc(validation.NoZeroValues),
 		"ip6_address":                    validation.ToDiagFunc(validation.IsIPv6Address),
 		"ip_address":                     validation.ToDiagFunc(validation.IsIPv4Address),
+		"manager_id":                     validation.ToDiagFunc(validation.StringIsEmpty),
+		"manager_type":                   validation.ToDiagFunc(validation.StringIsEmpty),
 		"memory":                         validation.ToDiagFunc(validation.NoZeroValues),
 		"private_network_ip_addresses.#": validateString("1"),
 		"size":                           validateString(testAccDataSourceComputeSize),
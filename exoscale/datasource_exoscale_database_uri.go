@@ -0,0 +1,143 @@
+package exoscale
+
+import (
+	"context"
+
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	dsDatabaseURIAttrDatabase = "database"
+	dsDatabaseURIAttrHost     = "host"
+	dsDatabaseURIAttrName     = "name"
+	dsDatabaseURIAttrPassword = "password"
+	dsDatabaseURIAttrPort     = "port"
+	dsDatabaseURIAttrSSLMode  = "sslmode"
+	dsDatabaseURIAttrType     = "type"
+	dsDatabaseURIAttrURI      = "uri"
+	dsDatabaseURIAttrUsername = "username"
+	dsDatabaseURIAttrZone     = "zone"
+)
+
+func dataSourceDatabaseURI() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			dsDatabaseURIAttrDatabase: {
+				Type:        schema.TypeString,
+				Description: "Name of the default database of the Database Service",
+				Computed:    true,
+			},
+			dsDatabaseURIAttrHost: {
+				Type:        schema.TypeString,
+				Description: "Host name of the Database Service",
+				Computed:    true,
+			},
+			dsDatabaseURIAttrName: {
+				Type:        schema.TypeString,
+				Description: "Name of the Database Service",
+				Required:    true,
+			},
+			dsDatabaseURIAttrPassword: {
+				Type:        schema.TypeString,
+				Description: "Password of the Database Service admin user",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			dsDatabaseURIAttrPort: {
+				Type:        schema.TypeInt,
+				Description: "Port of the Database Service",
+				Computed:    true,
+			},
+			dsDatabaseURIAttrSSLMode: {
+				Type:        schema.TypeString,
+				Description: "SSL mode required to connect to the Database Service (PostgreSQL only)",
+				Computed:    true,
+			},
+			dsDatabaseURIAttrType: {
+				Type:        schema.TypeString,
+				Description: "Type of the Database Service",
+				Computed:    true,
+			},
+			dsDatabaseURIAttrURI: {
+				Type:        schema.TypeString,
+				Description: "Connection URI of the Database Service",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			dsDatabaseURIAttrUsername: {
+				Type:        schema.TypeString,
+				Description: "User name of the Database Service admin user",
+				Computed:    true,
+			},
+			dsDatabaseURIAttrZone: {
+				Type:        schema.TypeString,
+				Description: "Zone of the Database Service",
+				Required:    true,
+			},
+		},
+
+		ReadContext: dataSourceDatabaseURIRead,
+	}
+}
+
+func dataSourceDatabaseURIRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	zone := d.Get(dsDatabaseURIAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	database, err := client.GetDatabaseService(ctx, zone, d.Get(dsDatabaseURIAttrName).(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if database.URI == nil {
+		return diag.Errorf("Database Service %q has no connection URI yet", *database.Name)
+	}
+
+	d.SetId(*database.Name)
+
+	if err := d.Set(dsDatabaseURIAttrType, defaultString(database.Type, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsDatabaseURIAttrURI, database.URI.String()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	uri, err := parseDatabaseServiceURI(database.URI)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsDatabaseURIAttrDatabase, uri.Database); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsDatabaseURIAttrHost, uri.Host); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsDatabaseURIAttrPassword, uri.Password); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsDatabaseURIAttrPort, uri.Port); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsDatabaseURIAttrSSLMode, uri.SSLMode); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsDatabaseURIAttrUsername, uri.Username); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package exoscale
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var (
+	testAccDataSourceDatabaseURIName = acctest.RandomWithPrefix(testPrefix)
+	testAccDataSourceDatabaseURIType = "pg"
+	testAccDataSourceDatabaseURIPlan = "hobbyist-1"
+)
+
+func TestAccDataSourceDatabaseURI(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+locals {
+  zone = "%s"
+}
+
+resource "exoscale_database" "test" {
+  zone = local.zone
+  name = "%s"
+  type = "%s"
+  plan = "%s"
+
+  timeouts {
+    create = "10m"
+  }
+}
+
+data "exoscale_database_uri" "test" {
+  zone = local.zone
+  name = exoscale_database.test.name
+}`,
+					testZoneName,
+					testAccDataSourceDatabaseURIName,
+					testAccDataSourceDatabaseURIType,
+					testAccDataSourceDatabaseURIPlan,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceDatabaseURIAttributes(testAttrs{
+						dsDatabaseURIAttrDatabase: validation.ToDiagFunc(validation.NoZeroValues),
+						dsDatabaseURIAttrHost:     validation.ToDiagFunc(validation.NoZeroValues),
+						dsDatabaseURIAttrName:     validateString(testAccDataSourceDatabaseURIName),
+						dsDatabaseURIAttrPassword: validation.ToDiagFunc(validation.NoZeroValues),
+						dsDatabaseURIAttrPort:     validation.ToDiagFunc(validation.NoZeroValues),
+						dsDatabaseURIAttrSSLMode:  validateString("require"),
+						dsDatabaseURIAttrType:     validateString(testAccDataSourceDatabaseURIType),
+						dsDatabaseURIAttrURI:      validation.ToDiagFunc(validation.IsURLWithScheme([]string{"postgres"})),
+						dsDatabaseURIAttrUsername: validation.ToDiagFunc(validation.NoZeroValues),
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceDatabaseURIAttributes(expected testAttrs) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for name, res := range s.RootModule().Resources {
+			if res.Type != "exoscale_database_uri" {
+				continue
+			}
+
+			if name != "data.exoscale_database_uri.test" {
+				continue
+			}
+
+			return checkResourceAttributes(expected, res.Primary.Attributes)
+		}
+
+		return errors.New("exoscale_database_uri data source not found in the state")
+	}
+}
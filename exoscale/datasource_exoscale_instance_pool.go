@@ -0,0 +1,274 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/exoscale/egoscale"
+	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	dsInstancePoolAttrDescription                = "description"
+	dsInstancePoolAttrID                         = "id"
+	dsInstancePoolAttrInstances                  = "instances"
+	dsInstancePoolAttrInstanceID                 = "id"
+	dsInstancePoolAttrInstanceIPv6Address        = "ipv6_address"
+	dsInstancePoolAttrInstanceName               = "name"
+	dsInstancePoolAttrInstancePrivateIPAddresses = "private_ip_addresses"
+	dsInstancePoolAttrInstancePublicIPAddress    = "public_ip_address"
+	dsInstancePoolAttrInstanceType               = "instance_type"
+	dsInstancePoolAttrIPv6                       = "ipv6"
+	dsInstancePoolAttrLabels                     = "labels"
+	dsInstancePoolAttrName                       = "name"
+	dsInstancePoolAttrSize                       = "size"
+	dsInstancePoolAttrState                      = "state"
+	dsInstancePoolAttrTemplateID                 = "template_id"
+	dsInstancePoolAttrZone                       = "zone"
+)
+
+func dataSourceInstancePool() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			dsInstancePoolAttrDescription: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			dsInstancePoolAttrID: {
+				Type:          schema.TypeString,
+				Description:   "ID of the Instance Pool",
+				Optional:      true,
+				ConflictsWith: []string{dsInstancePoolAttrName, dsInstancePoolAttrLabels},
+			},
+			dsInstancePoolAttrInstances: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						dsInstancePoolAttrInstanceID: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsInstancePoolAttrInstanceIPv6Address: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsInstancePoolAttrInstanceName: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsInstancePoolAttrInstancePrivateIPAddresses: {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						dsInstancePoolAttrInstancePublicIPAddress: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			dsInstancePoolAttrInstanceType: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			dsInstancePoolAttrIPv6: {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			dsInstancePoolAttrLabels: {
+				Type:          schema.TypeMap,
+				Description:   "Labels of the Instance Pool",
+				Optional:      true,
+				Computed:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{dsInstancePoolAttrID, dsInstancePoolAttrName},
+			},
+			dsInstancePoolAttrName: {
+				Type:          schema.TypeString,
+				Description:   "Name of the Instance Pool",
+				Optional:      true,
+				ConflictsWith: []string{dsInstancePoolAttrID, dsInstancePoolAttrLabels},
+			},
+			dsInstancePoolAttrSize: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			dsInstancePoolAttrState: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			dsInstancePoolAttrTemplateID: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			dsInstancePoolAttrZone: {
+				Type:        schema.TypeString,
+				Description: "Zone of the Instance Pool",
+				Required:    true,
+			},
+		},
+
+		ReadContext: dataSourceInstancePoolRead,
+	}
+}
+
+func dataSourceInstancePoolRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	zone := d.Get(dsInstancePoolAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	var (
+		instancePool *exov2.InstancePool
+		err          error
+	)
+
+	_, byID := d.GetOk(dsInstancePoolAttrID)
+	_, byName := d.GetOk(dsInstancePoolAttrName)
+	labels, byLabels := d.GetOk(dsInstancePoolAttrLabels)
+	switch {
+	case byID:
+		instancePool, err = client.FindInstancePool(ctx, zone, d.Get(dsInstancePoolAttrID).(string))
+
+	case byName:
+		instancePool, err = client.FindInstancePool(ctx, zone, d.Get(dsInstancePoolAttrName).(string))
+
+	case byLabels:
+		instancePool, err = findInstancePoolByLabels(ctx, client, zone, labels.(map[string]interface{}))
+
+	default:
+		return diag.FromErr(errors.New("either name, id or labels must be specified"))
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(*instancePool.ID)
+
+	if err := d.Set(dsInstancePoolAttrID, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsInstancePoolAttrDescription, defaultString(instancePool.Description, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	instanceType, err := client.GetInstanceType(ctx, zone, *instancePool.InstanceTypeID)
+	if err != nil {
+		return diag.Errorf("unable to retrieve instance type: %s", err)
+	}
+	if err := d.Set(dsInstancePoolAttrInstanceType, fmt.Sprintf(
+		"%s.%s",
+		strings.ToLower(*instanceType.Family),
+		strings.ToLower(*instanceType.Size),
+	)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsInstancePoolAttrIPv6, defaultBool(instancePool.IPv6Enabled, false)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if instancePool.Labels != nil {
+		if err := d.Set(dsInstancePoolAttrLabels, *instancePool.Labels); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := d.Set(dsInstancePoolAttrName, *instancePool.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsInstancePoolAttrSize, defaultInt64(instancePool.Size, 0)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsInstancePoolAttrState, defaultString(instancePool.State, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsInstancePoolAttrTemplateID, defaultString(instancePool.TemplateID, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Private Network IP addresses are only known for managed Private Networks,
+	// from their DHCP leases.
+	privateIPAddresses := make(map[string][]string)
+	if instancePool.PrivateNetworkIDs != nil {
+		for _, id := range *instancePool.PrivateNetworkIDs {
+			privateNetwork, err := client.GetPrivateNetwork(ctx, zone, id)
+			if err != nil {
+				return diag.Errorf("unable to retrieve Private Network %s: %s", id, err)
+			}
+
+			for _, lease := range privateNetwork.Leases {
+				instanceID := defaultString(lease.InstanceID, "")
+				privateIPAddresses[instanceID] = append(privateIPAddresses[instanceID], lease.IPAddress.String())
+			}
+		}
+	}
+
+	instances := make([]map[string]interface{}, 0)
+	if instancePool.InstanceIDs != nil {
+		for _, id := range *instancePool.InstanceIDs {
+			instance, err := client.GetInstance(ctx, zone, id)
+			if err != nil {
+				return diag.Errorf("unable to retrieve Compute instance %s: %s", id, err)
+			}
+
+			var publicIPAddress, ipv6Address string
+			if instance.PublicIPAddress != nil {
+				publicIPAddress = instance.PublicIPAddress.String()
+			}
+			if instance.IPv6Address != nil {
+				ipv6Address = instance.IPv6Address.String()
+			}
+
+			instances = append(instances, map[string]interface{}{
+				dsInstancePoolAttrInstanceID:                 id,
+				dsInstancePoolAttrInstanceIPv6Address:        ipv6Address,
+				dsInstancePoolAttrInstanceName:               defaultString(instance.Name, ""),
+				dsInstancePoolAttrInstancePrivateIPAddresses: privateIPAddresses[id],
+				dsInstancePoolAttrInstancePublicIPAddress:    publicIPAddress,
+			})
+		}
+	}
+	if err := d.Set(dsInstancePoolAttrInstances, instances); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// findInstancePoolByLabels returns the only Instance Pool of the zone matching
+// all the specified labels.
+func findInstancePoolByLabels(
+	ctx context.Context,
+	client *egoscale.Client,
+	zone string,
+	labels map[string]interface{},
+) (*exov2.InstancePool, error) {
+	instancePools, err := client.ListInstancePools(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	i, err := findByLabels("Instance Pool", labels, len(instancePools), func(i int) *map[string]string {
+		return instancePools[i].Labels
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return instancePools[i], nil
+}
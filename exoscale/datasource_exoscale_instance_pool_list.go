@@ -0,0 +1,167 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	dsInstancePoolListAttrIDs                       = "ids"
+	dsInstancePoolListAttrInstancePools             = "instance_pools"
+	dsInstancePoolListAttrInstancePoolID            = "id"
+	dsInstancePoolListAttrInstancePoolName          = "name"
+	dsInstancePoolListAttrInstancePoolSKSNodepoolID = "sks_nodepool_id"
+	dsInstancePoolListAttrLabels                    = "labels"
+	dsInstancePoolListAttrZone                      = "zone"
+)
+
+func dataSourceInstancePoolList() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			dsInstancePoolListAttrIDs: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			dsInstancePoolListAttrInstancePools: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						dsInstancePoolListAttrInstancePoolID: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsInstancePoolListAttrInstancePoolName: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsInstancePoolListAttrInstancePoolSKSNodepoolID: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			dsInstancePoolListAttrLabels: {
+				Type:        schema.TypeMap,
+				Description: "Labels the Instance Pools (or SKS Nodepools) must all have to be selected",
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			dsInstancePoolListAttrZone: {
+				Type:        schema.TypeString,
+				Description: "Zone of the Instance Pools",
+				Required:    true,
+			},
+		},
+
+		ReadContext: dataSourceInstancePoolListRead,
+	}
+}
+
+func dataSourceInstancePoolListRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	zone := d.Get(dsInstancePoolListAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	selector := make(map[string]string)
+	for k, v := range d.Get(dsInstancePoolListAttrLabels).(map[string]interface{}) {
+		selector[k] = v.(string)
+	}
+
+	// Instance Pools managed by SKS Nodepools are selected by the labels of
+	// their Nodepool, as these are not applied to the Instance Pool itself.
+	selected := make(map[string]map[string]interface{})
+
+	var diags diag.Diagnostics
+
+	instancePools, err := client.ListInstancePools(ctx, zone)
+	if err != nil {
+		warning := unavailableServiceWarning(meta, "Instance Pools", err)
+		if warning == nil {
+			return diag.Errorf("unable to list Instance Pools: %s", err)
+		}
+		diags = append(diags, warning...)
+	}
+	for _, instancePool := range instancePools {
+		if instancePool.Labels == nil || !labelsMatch(*instancePool.Labels, selector) {
+			continue
+		}
+
+		selected[*instancePool.ID] = map[string]interface{}{
+			dsInstancePoolListAttrInstancePoolID:            *instancePool.ID,
+			dsInstancePoolListAttrInstancePoolName:          defaultString(instancePool.Name, ""),
+			dsInstancePoolListAttrInstancePoolSKSNodepoolID: "",
+		}
+	}
+
+	clusters, err := client.ListSKSClusters(ctx, zone)
+	if err != nil {
+		warning := unavailableServiceWarning(meta, "SKS", err)
+		if warning == nil {
+			return diag.Errorf("unable to list SKS clusters: %s", err)
+		}
+		diags = append(diags, warning...)
+	}
+	for _, cluster := range clusters {
+		for _, nodepool := range cluster.Nodepools {
+			if nodepool.InstancePoolID == nil ||
+				nodepool.Labels == nil ||
+				!labelsMatch(*nodepool.Labels, selector) {
+				continue
+			}
+
+			selected[*nodepool.InstancePoolID] = map[string]interface{}{
+				dsInstancePoolListAttrInstancePoolID:            *nodepool.InstancePoolID,
+				dsInstancePoolListAttrInstancePoolName:          defaultString(nodepool.Name, ""),
+				dsInstancePoolListAttrInstancePoolSKSNodepoolID: defaultString(nodepool.ID, ""),
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(selected))
+	for id := range selected {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	pools := make([]map[string]interface{}, len(ids))
+	for i, id := range ids {
+		pools[i] = selected[id]
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", zone, labelsSelectorString(selector)))
+
+	if err := d.Set(dsInstancePoolListAttrIDs, ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsInstancePoolListAttrInstancePools, pools); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// labelsSelectorString returns a stable string representation of a labels
+// selector (e.g. "app=web,env=prod").
+func labelsSelectorString(selector map[string]string) string {
+	pairs := make([]string, 0, len(selector))
+	for k, v := range selector {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,109 @@
+package exoscale
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var (
+	testAccDataSourceInstancePoolListInstancePoolName = acctest.RandomWithPrefix(testPrefix)
+	testAccDataSourceInstancePoolListLabelValue       = acctest.RandomWithPrefix(testPrefix)
+
+	testAccDataSourceInstancePoolListResourceConfig = fmt.Sprintf(`
+locals {
+  zone = "%s"
+}
+
+resource "exoscale_instance_pool" "test" {
+  zone = local.zone
+  name = "%s"
+  template_id = "%s"
+  instance_type = "standard.tiny"
+  size = 1
+  labels = {
+    test = "%s"
+  }
+
+  timeouts {
+    delete = "10m"
+  }
+}`,
+		testZoneName,
+		testAccDataSourceInstancePoolListInstancePoolName,
+		testInstanceTemplateID,
+		testAccDataSourceInstancePoolListLabelValue,
+	)
+)
+
+func TestLabelsSelectorString(t *testing.T) {
+	for _, tt := range []struct {
+		selector map[string]string
+		expected string
+	}{
+		{selector: map[string]string{}, expected: ""},
+		{selector: map[string]string{"role": "web"}, expected: "role=web"},
+		{selector: map[string]string{"role": "web", "env": "prod"}, expected: "env=prod,role=web"},
+	} {
+		if actual := labelsSelectorString(tt.selector); actual != tt.expected {
+			t.Errorf("bad selector string, wanted %q, got %q", tt.expected, actual)
+		}
+	}
+}
+
+func TestAccDataSourceInstancePoolList(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`%s
+data "exoscale_instance_pool_list" "match" {
+  zone = local.zone
+  labels = exoscale_instance_pool.test.labels
+}`,
+					testAccDataSourceInstancePoolListResourceConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceInstancePoolListAttributes("data.exoscale_instance_pool_list.match", testAttrs{
+						dsInstancePoolListAttrIDs + ".#":                           validateString("1"),
+						dsInstancePoolListAttrIDs + ".0":                           validation.ToDiagFunc(validation.IsUUID),
+						dsInstancePoolListAttrInstancePools + ".#":                 validateString("1"),
+						dsInstancePoolListAttrInstancePools + ".0.name":            validateString(testAccDataSourceInstancePoolListInstancePoolName),
+						dsInstancePoolListAttrInstancePools + ".0.sks_nodepool_id": validation.ToDiagFunc(validation.StringIsEmpty),
+					}),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`%s
+data "exoscale_instance_pool_list" "no-match" {
+  zone = local.zone
+  labels = {
+    test = "${exoscale_instance_pool.test.labels.test}-nope"
+  }
+}`,
+					testAccDataSourceInstancePoolListResourceConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceInstancePoolListAttributes("data.exoscale_instance_pool_list.no-match", testAttrs{
+						dsInstancePoolListAttrIDs + ".#": validateString("0"),
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceInstancePoolListAttributes(r string, expected testAttrs) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ds, ok := s.RootModule().Resources[r]
+		if !ok {
+			return errors.New("data source not found in the state")
+		}
+
+		return checkResourceAttributes(expected, ds.Primary.Attributes)
+	}
+}
@@ -0,0 +1,110 @@
+package exoscale
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var (
+	testAccDataSourceInstancePoolName         = acctest.RandomWithPrefix(testPrefix)
+	testAccDataSourceInstancePoolInstanceType = "standard.tiny"
+	testAccDataSourceInstancePoolSize         = 1
+
+	testAccDataSourceInstancePoolResourceConfig = fmt.Sprintf(`
+locals {
+  zone = "%s"
+}
+
+data "exoscale_compute_template" "ubuntu" {
+  zone = local.zone
+  name = "Linux Ubuntu 20.04 LTS 64-bit"
+}
+
+resource "exoscale_instance_pool" "test" {
+  zone = local.zone
+  name = "%s"
+  template_id = data.exoscale_compute_template.ubuntu.id
+  instance_type = "%s"
+  size = %d
+  ipv6 = true
+
+  timeouts {
+    delete = "10m"
+  }
+}`,
+		testZoneName,
+		testAccDataSourceInstancePoolName,
+		testAccDataSourceInstancePoolInstanceType,
+		testAccDataSourceInstancePoolSize,
+	)
+)
+
+func TestAccDataSourceInstancePool(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`%s
+data "exoscale_instance_pool" "test" {
+  zone = local.zone
+}`,
+					testAccDataSourceInstancePoolResourceConfig),
+				ExpectError: regexp.MustCompile("either name, id or labels must be specified"),
+			},
+			{
+				Config: fmt.Sprintf(`%s
+data "exoscale_instance_pool" "by-id" {
+  zone = local.zone
+  id = exoscale_instance_pool.test.id
+}`,
+					testAccDataSourceInstancePoolResourceConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceInstancePoolAttributes("data.exoscale_instance_pool.by-id", testAttrs{
+						dsInstancePoolAttrID:               validation.ToDiagFunc(validation.IsUUID),
+						dsInstancePoolAttrInstanceType:     validateString(testAccDataSourceInstancePoolInstanceType),
+						dsInstancePoolAttrIPv6:             validateString("true"),
+						dsInstancePoolAttrName:             validateString(testAccDataSourceInstancePoolName),
+						dsInstancePoolAttrSize:             validateString(fmt.Sprint(testAccDataSourceInstancePoolSize)),
+						dsInstancePoolAttrInstances + ".#": validateString(fmt.Sprint(testAccDataSourceInstancePoolSize)),
+						dsInstancePoolAttrInstances + ".0." + dsInstancePoolAttrInstanceID:              validation.ToDiagFunc(validation.IsUUID),
+						dsInstancePoolAttrInstances + ".0." + dsInstancePoolAttrInstanceIPv6Address:     validation.ToDiagFunc(validation.IsIPv6Address),
+						dsInstancePoolAttrInstances + ".0." + dsInstancePoolAttrInstancePublicIPAddress: validation.ToDiagFunc(validation.IsIPv4Address),
+					}),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`%s
+data "exoscale_instance_pool" "by-name" {
+  zone = local.zone
+  name = exoscale_instance_pool.test.name
+}`,
+					testAccDataSourceInstancePoolResourceConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceInstancePoolAttributes("data.exoscale_instance_pool.by-name", testAttrs{
+						dsInstancePoolAttrID:   validation.ToDiagFunc(validation.IsUUID),
+						dsInstancePoolAttrName: validateString(testAccDataSourceInstancePoolName),
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceInstancePoolAttributes(r string, expected testAttrs) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ds, ok := s.RootModule().Resources[r]
+		if !ok {
+			return errors.New("data source not found in the state")
+		}
+
+		return checkResourceAttributes(expected, ds.Primary.Attributes)
+	}
+}
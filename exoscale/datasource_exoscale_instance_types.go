@@ -0,0 +1,217 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	dsInstanceTypesAttrFamily                      = "family"
+	dsInstanceTypesAttrInstanceTypes               = "instance_types"
+	dsInstanceTypesAttrInstanceTypeAuthorizedZones = "authorized_zones"
+	dsInstanceTypesAttrInstanceTypeCPUs            = "cpus"
+	dsInstanceTypesAttrInstanceTypeFamily          = "family"
+	dsInstanceTypesAttrInstanceTypeGPUs            = "gpus"
+	dsInstanceTypesAttrInstanceTypeID              = "id"
+	dsInstanceTypesAttrInstanceTypeMemory          = "memory"
+	dsInstanceTypesAttrInstanceTypeName            = "name"
+	dsInstanceTypesAttrInstanceTypeSize            = "size"
+	dsInstanceTypesAttrInstanceTypeZones           = "zones"
+	dsInstanceTypesAttrZones                       = "zones"
+)
+
+func dataSourceInstanceTypes() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			dsInstanceTypesAttrFamily: {
+				Type:        schema.TypeString,
+				Description: "Family of the instance types to list",
+				Optional:    true,
+			},
+			dsInstanceTypesAttrInstanceTypes: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						dsInstanceTypesAttrInstanceTypeAuthorizedZones: {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						dsInstanceTypesAttrInstanceTypeCPUs: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						dsInstanceTypesAttrInstanceTypeFamily: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsInstanceTypesAttrInstanceTypeGPUs: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						dsInstanceTypesAttrInstanceTypeID: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsInstanceTypesAttrInstanceTypeMemory: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						dsInstanceTypesAttrInstanceTypeName: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsInstanceTypesAttrInstanceTypeSize: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsInstanceTypesAttrInstanceTypeZones: {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			dsInstanceTypesAttrZones: {
+				Type:        schema.TypeList,
+				Description: "Zones to list the instance types of (default: all zones)",
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+
+		ReadContext: dataSourceInstanceTypesRead,
+	}
+}
+
+func dataSourceInstanceTypesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	zones, err := client.ListZones(exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), defaultZone)))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to list zones: %w", err))
+	}
+
+	if v, ok := d.GetOk(dsInstanceTypesAttrZones); ok {
+		selected := make([]string, 0)
+		for _, z := range v.([]interface{}) {
+			zone := z.(string)
+			if !in(zones, zone) {
+				return diag.Errorf("zone %q not found (available zones: %s)", zone, strings.Join(zones, ", "))
+			}
+			selected = append(selected, zone)
+		}
+		zones = selected
+	}
+	sort.Strings(zones)
+
+	var diags diag.Diagnostics
+
+	instanceTypesByZone := make(map[string][]*exov2.InstanceType, len(zones))
+	for _, zone := range zones {
+		instanceTypes, err := client.ListInstanceTypes(
+			exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone)),
+			zone,
+		)
+		if err != nil {
+			warning := unavailableServiceWarning(meta, "Compute ("+zone+")", err)
+			if warning == nil {
+				return diag.FromErr(fmt.Errorf("unable to list instance types in zone %s: %w", zone, err))
+			}
+			diags = append(diags, warning...)
+		}
+		instanceTypesByZone[zone] = instanceTypes
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", strings.Join(zones, ","), d.Get(dsInstanceTypesAttrFamily).(string)))
+
+	if err := d.Set(dsInstanceTypesAttrInstanceTypes, flattenInstanceTypes(
+		zones,
+		instanceTypesByZone,
+		d.Get(dsInstanceTypesAttrFamily).(string),
+	)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsInstanceTypesAttrZones, zones); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// flattenInstanceTypes merges the instance types listed in the specified zones
+// (optionally restricted to a family) into a list sorted by name, along with
+// the zones each instance type is available and authorized in.
+func flattenInstanceTypes(zones []string, instanceTypesByZone map[string][]*exov2.InstanceType, family string) []map[string]interface{} {
+	byName := make(map[string]map[string]interface{})
+
+	for _, zone := range zones {
+		for _, instanceType := range instanceTypesByZone[zone] {
+			if family != "" && !strings.EqualFold(defaultString(instanceType.Family, ""), family) {
+				continue
+			}
+
+			name := instanceTypeName(instanceType)
+			v, ok := byName[name]
+			if !ok {
+				v = map[string]interface{}{
+					dsInstanceTypesAttrInstanceTypeAuthorizedZones: make([]string, 0),
+					dsInstanceTypesAttrInstanceTypeCPUs:            int(defaultInt64(instanceType.CPUs, 0)),
+					dsInstanceTypesAttrInstanceTypeFamily:          strings.ToLower(defaultString(instanceType.Family, "")),
+					dsInstanceTypesAttrInstanceTypeGPUs:            int(defaultInt64(instanceType.GPUs, 0)),
+					dsInstanceTypesAttrInstanceTypeID:              defaultString(instanceType.ID, ""),
+					dsInstanceTypesAttrInstanceTypeMemory:          int(defaultInt64(instanceType.Memory, 0)),
+					dsInstanceTypesAttrInstanceTypeName:            name,
+					dsInstanceTypesAttrInstanceTypeSize:            strings.ToLower(defaultString(instanceType.Size, "")),
+					dsInstanceTypesAttrInstanceTypeZones:           make([]string, 0),
+				}
+				byName[name] = v
+			}
+
+			v[dsInstanceTypesAttrInstanceTypeZones] = append(v[dsInstanceTypesAttrInstanceTypeZones].([]string), zone)
+			if defaultBool(instanceType.Authorized, true) {
+				v[dsInstanceTypesAttrInstanceTypeAuthorizedZones] = append(
+					v[dsInstanceTypesAttrInstanceTypeAuthorizedZones].([]string),
+					zone,
+				)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	instanceTypes := make([]map[string]interface{}, len(names))
+	for i, name := range names {
+		instanceTypes[i] = byName[name]
+	}
+
+	return instanceTypes
+}
+
+// instanceTypeName returns the name of an instance type in the "FAMILY.SIZE"
+// format used in resources configuration (e.g. "standard.medium").
+func instanceTypeName(instanceType *exov2.InstanceType) string {
+	return fmt.Sprintf(
+		"%s.%s",
+		strings.ToLower(defaultString(instanceType.Family, "")),
+		strings.ToLower(defaultString(instanceType.Size, "")),
+	)
+}
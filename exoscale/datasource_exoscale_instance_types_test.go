@@ -0,0 +1,88 @@
+package exoscale
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	exov2 "github.com/exoscale/egoscale/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestFlattenInstanceTypes(t *testing.T) {
+	newInstanceType := func(family, size string, authorized bool) *exov2.InstanceType {
+		id := family + "-" + size
+		return &exov2.InstanceType{ID: &id, Family: &family, Size: &size, Authorized: &authorized}
+	}
+
+	instanceTypesByZone := map[string][]*exov2.InstanceType{
+		"ch-gva-2": {
+			newInstanceType("standard", "tiny", true),
+			newInstanceType("gpu", "small", false),
+		},
+		"de-fra-1": {
+			newInstanceType("standard", "tiny", true),
+			newInstanceType("gpu", "small", true),
+		},
+	}
+
+	instanceTypes := flattenInstanceTypes([]string{"ch-gva-2", "de-fra-1"}, instanceTypesByZone, "")
+	if len(instanceTypes) != 2 {
+		t.Fatalf("expected 2 instance types, got %d", len(instanceTypes))
+	}
+
+	gpu := instanceTypes[0]
+	if gpu[dsInstanceTypesAttrInstanceTypeName] != "gpu.small" {
+		t.Errorf("expected gpu.small, got %v", gpu[dsInstanceTypesAttrInstanceTypeName])
+	}
+	if zones := gpu[dsInstanceTypesAttrInstanceTypeZones]; !reflect.DeepEqual(zones, []string{"ch-gva-2", "de-fra-1"}) {
+		t.Errorf("bad zones: %v", zones)
+	}
+	if zones := gpu[dsInstanceTypesAttrInstanceTypeAuthorizedZones]; !reflect.DeepEqual(zones, []string{"de-fra-1"}) {
+		t.Errorf("bad authorized zones: %v", zones)
+	}
+
+	instanceTypes = flattenInstanceTypes([]string{"ch-gva-2", "de-fra-1"}, instanceTypesByZone, "Standard")
+	if len(instanceTypes) != 1 || instanceTypes[0][dsInstanceTypesAttrInstanceTypeName] != "standard.tiny" {
+		t.Errorf("expected only standard.tiny, got %v", instanceTypes)
+	}
+}
+
+func TestAccDataSourceInstanceTypes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "exoscale_instance_types" "test" {
+  zones  = ["%s"]
+  family = "standard"
+}`,
+					testZoneName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceInstanceTypesAttributes("data.exoscale_instance_types.test", testAttrs{
+						dsInstanceTypesAttrZones + ".#":                            validateString("1"),
+						dsInstanceTypesAttrZones + ".0":                            validateString(testZoneName),
+						dsInstanceTypesAttrInstanceTypes + ".0.family":             validateString("standard"),
+						dsInstanceTypesAttrInstanceTypes + ".0.zones.0":            validateString(testZoneName),
+						dsInstanceTypesAttrInstanceTypes + ".0.authorized_zones.#": validateString("1"),
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceInstanceTypesAttributes(r string, expected testAttrs) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ds, ok := s.RootModule().Resources[r]
+		if !ok {
+			return errors.New("data source not found in the state")
+		}
+
+		return checkResourceAttributes(expected, ds.Primary.Attributes)
+	}
+}
@@ -0,0 +1,354 @@
+package exoscale
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	dsInventoryAttrInstances                   = "instances"
+	dsInventoryAttrInstanceID                  = "id"
+	dsInventoryAttrInstanceIPv6Address         = "ipv6_address"
+	dsInventoryAttrInstanceInstancePoolID      = "instance_pool_id"
+	dsInventoryAttrInstanceLabels              = "labels"
+	dsInventoryAttrInstanceName                = "name"
+	dsInventoryAttrInstancePublicIPAddress     = "public_ip_address"
+	dsInventoryAttrINI                         = "ini"
+	dsInventoryAttrJSON                        = "json"
+	dsInventoryAttrLabels                      = "labels"
+	dsInventoryAttrZone                        = "zone"
+	dsInventoryUngroupedGroup                  = "ungrouped"
+	dsInventoryInstancePoolGroupPrefix         = "pool_"
+	dsInventoryInstanceManagerTypeInstancePool = "instance-pool"
+)
+
+// inventoryGroupNameSanitizer matches the characters not allowed in Ansible
+// inventory group names.
+var inventoryGroupNameSanitizer = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// inventoryHost represents a Compute instance rendered in an inventory.
+type inventoryHost struct {
+	ID      string
+	Name    string
+	Group   string
+	Address string
+	Labels  map[string]string
+	Zone    string
+}
+
+func dataSourceInventory() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			dsInventoryAttrInstances: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						dsInventoryAttrInstanceID: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsInventoryAttrInstanceInstancePoolID: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsInventoryAttrInstanceIPv6Address: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsInventoryAttrInstanceLabels: {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						dsInventoryAttrInstanceName: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsInventoryAttrInstancePublicIPAddress: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			dsInventoryAttrINI: {
+				Type:        schema.TypeString,
+				Description: "Inventory rendered in the Ansible INI format",
+				Computed:    true,
+			},
+			dsInventoryAttrJSON: {
+				Type:        schema.TypeString,
+				Description: "Inventory rendered in the Ansible dynamic inventory JSON format",
+				Computed:    true,
+			},
+			dsInventoryAttrLabels: {
+				Type: schema.TypeMap,
+				Description: "Labels the Compute instances (or their Instance Pool) must match " +
+					"to be part of the inventory",
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			dsInventoryAttrZone: {
+				Type:        schema.TypeString,
+				Description: "Zone of the Compute instances",
+				Required:    true,
+			},
+		},
+
+		ReadContext: dataSourceInventoryRead,
+	}
+}
+
+func dataSourceInventoryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	zone := d.Get(dsInventoryAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	filter := make(map[string]string)
+	for k, v := range d.Get(dsInventoryAttrLabels).(map[string]interface{}) {
+		filter[k] = v.(string)
+	}
+
+	var diags diag.Diagnostics
+
+	instancePools, err := client.ListInstancePools(ctx, zone)
+	if err != nil {
+		warning := unavailableServiceWarning(meta, "Instance Pools", err)
+		if warning == nil {
+			return diag.FromErr(fmt.Errorf("unable to list Instance Pools: %w", err))
+		}
+		diags = append(diags, warning...)
+	}
+	poolsByID := make(map[string]*exov2.InstancePool, len(instancePools))
+	for _, instancePool := range instancePools {
+		poolsByID[*instancePool.ID] = instancePool
+	}
+
+	instances, err := client.ListInstances(ctx, zone)
+	if err != nil {
+		warning := unavailableServiceWarning(meta, "Compute", err)
+		if warning == nil {
+			return diag.FromErr(fmt.Errorf("unable to list Compute instances: %w", err))
+		}
+		diags = append(diags, warning...)
+	}
+
+	hosts := make([]inventoryHost, 0)
+	instancesList := make([]map[string]interface{}, 0)
+	for _, instance := range instances {
+		labels := make(map[string]string)
+		if instance.Labels != nil {
+			labels = *instance.Labels
+		}
+
+		var instancePool *exov2.InstancePool
+		if instance.Manager != nil && instance.Manager.Type == dsInventoryInstanceManagerTypeInstancePool {
+			instancePool = poolsByID[instance.Manager.ID]
+		}
+
+		matches := labelsMatch(labels, filter)
+		if !matches && instancePool != nil && instancePool.Labels != nil {
+			matches = labelsMatch(*instancePool.Labels, filter)
+		}
+		if !matches {
+			continue
+		}
+
+		host := inventoryHost{
+			ID:     *instance.ID,
+			Name:   defaultString(instance.Name, *instance.ID),
+			Group:  dsInventoryUngroupedGroup,
+			Labels: labels,
+			Zone:   zone,
+		}
+
+		var publicIPAddress, ipv6Address, instancePoolID string
+		if instance.PublicIPAddress != nil {
+			publicIPAddress = instance.PublicIPAddress.String()
+			host.Address = publicIPAddress
+		}
+		if instance.IPv6Address != nil {
+			ipv6Address = instance.IPv6Address.String()
+			if host.Address == "" {
+				host.Address = ipv6Address
+			}
+		}
+		if instancePool != nil {
+			instancePoolID = *instancePool.ID
+			host.Group = inventoryGroupName(*instancePool.Name)
+		}
+
+		hosts = append(hosts, host)
+		instancesList = append(instancesList, map[string]interface{}{
+			dsInventoryAttrInstanceID:              *instance.ID,
+			dsInventoryAttrInstanceInstancePoolID:  instancePoolID,
+			dsInventoryAttrInstanceIPv6Address:     ipv6Address,
+			dsInventoryAttrInstanceLabels:          labels,
+			dsInventoryAttrInstanceName:            host.Name,
+			dsInventoryAttrInstancePublicIPAddress: publicIPAddress,
+		})
+	}
+
+	hosts = uniqueInventoryHostNames(hosts)
+
+	inventoryJSON, err := renderInventoryJSON(hosts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(zone)
+
+	if err := d.Set(dsInventoryAttrInstances, instancesList); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsInventoryAttrINI, renderInventoryINI(hosts)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsInventoryAttrJSON, inventoryJSON); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// labelsMatch returns true if labels contain all the key/value pairs of filter.
+func labelsMatch(labels, filter map[string]string) bool {
+	for k, v := range filter {
+		if lv, ok := labels[k]; !ok || lv != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// inventoryGroupName returns the inventory group name of an Instance Pool: the
+// characters not allowed by Ansible are replaced, and the name is prefixed so
+// that it can't start with a digit nor clash with the built-in "all" and
+// "ungrouped" groups.
+func inventoryGroupName(instancePoolName string) string {
+	return dsInventoryInstancePoolGroupPrefix + inventoryGroupNameSanitizer.ReplaceAllString(instancePoolName, "_")
+}
+
+// uniqueInventoryHostNames returns hosts with the names shared by several
+// hosts (Compute instance names not being unique) suffixed with the first
+// characters of the Compute instance ID, so that they don't overwrite each
+// other in the inventory.
+func uniqueInventoryHostNames(hosts []inventoryHost) []inventoryHost {
+	count := make(map[string]int, len(hosts))
+	for _, host := range hosts {
+		count[host.Name]++
+	}
+
+	res := make([]inventoryHost, len(hosts))
+	for i, host := range hosts {
+		if count[host.Name] > 1 {
+			shortID := host.ID
+			if len(shortID) > 8 {
+				shortID = shortID[:8]
+			}
+			host.Name = host.Name + "-" + shortID
+		}
+		res[i] = host
+	}
+
+	return res
+}
+
+// groupInventoryHosts returns the inventory hosts indexed by group, along with
+// the sorted list of group names.
+func groupInventoryHosts(hosts []inventoryHost) (map[string][]inventoryHost, []string) {
+	groups := make(map[string][]inventoryHost)
+	for _, host := range hosts {
+		groups[host.Group] = append(groups[host.Group], host)
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+		sort.Slice(groups[name], func(i, j int) bool { return groups[name][i].Name < groups[name][j].Name })
+	}
+	sort.Strings(names)
+
+	return groups, names
+}
+
+// inventoryHostVars returns the Ansible variables of an inventory host.
+func inventoryHostVars(host inventoryHost) map[string]interface{} {
+	vars := map[string]interface{}{
+		"exoscale_id":     host.ID,
+		"exoscale_labels": host.Labels,
+		"exoscale_zone":   host.Zone,
+	}
+	if host.Address != "" {
+		vars["ansible_host"] = host.Address
+	}
+
+	return vars
+}
+
+// renderInventoryJSON renders hosts in the Ansible dynamic inventory JSON format.
+func renderInventoryJSON(hosts []inventoryHost) (string, error) {
+	groups, names := groupInventoryHosts(hosts)
+
+	hostVars := make(map[string]interface{}, len(hosts))
+	inventory := map[string]interface{}{
+		"_meta": map[string]interface{}{"hostvars": hostVars},
+		"all":   map[string]interface{}{"children": names},
+	}
+
+	for _, name := range names {
+		groupHosts := make([]string, len(groups[name]))
+		for i, host := range groups[name] {
+			groupHosts[i] = host.Name
+			hostVars[host.Name] = inventoryHostVars(host)
+		}
+		inventory[name] = map[string]interface{}{"hosts": groupHosts}
+	}
+
+	out, err := json.Marshal(inventory)
+	if err != nil {
+		return "", fmt.Errorf("unable to render inventory: %w", err)
+	}
+
+	return string(out), nil
+}
+
+// renderInventoryINI renders hosts in the Ansible INI inventory format.
+func renderInventoryINI(hosts []inventoryHost) string {
+	groups, names := groupInventoryHosts(hosts)
+
+	var b strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "[%s]\n", name)
+
+		for _, host := range groups[name] {
+			b.WriteString(host.Name)
+			if host.Address != "" {
+				fmt.Fprintf(&b, " ansible_host=%s", host.Address)
+			}
+			fmt.Fprintf(&b, " exoscale_id=%s exoscale_zone=%s\n", host.ID, host.Zone)
+		}
+	}
+
+	return b.String()
+}
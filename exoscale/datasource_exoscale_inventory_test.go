@@ -0,0 +1,140 @@
+package exoscale
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+var testAccDataSourceInventoryLabelValue = acctest.RandomWithPrefix(testPrefix)
+
+func TestRenderInventory(t *testing.T) {
+	hosts := []inventoryHost{
+		{
+			ID:      "f0c7dc24-0d5e-4d4c-9b5f-7c8d5b6f6a1e",
+			Name:    "web-2",
+			Group:   "pool_web",
+			Address: "192.0.2.2",
+			Labels:  map[string]string{"app": "web"},
+			Zone:    "ch-gva-2",
+		},
+		{
+			ID:      "0b6a5c1e-3d2f-4e8a-9c7b-1a2b3c4d5e6f",
+			Name:    "web-1",
+			Group:   "pool_web",
+			Address: "192.0.2.1",
+			Labels:  map[string]string{"app": "web"},
+			Zone:    "ch-gva-2",
+		},
+		{
+			ID:     "6d5c4b3a-2f1e-4d0c-8b9a-7f6e5d4c3b2a",
+			Name:   "bastion",
+			Group:  dsInventoryUngroupedGroup,
+			Labels: map[string]string{},
+			Zone:   "ch-gva-2",
+		},
+	}
+
+	require.Equal(t, `[pool_web]
+web-1 ansible_host=192.0.2.1 exoscale_id=0b6a5c1e-3d2f-4e8a-9c7b-1a2b3c4d5e6f exoscale_zone=ch-gva-2
+web-2 ansible_host=192.0.2.2 exoscale_id=f0c7dc24-0d5e-4d4c-9b5f-7c8d5b6f6a1e exoscale_zone=ch-gva-2
+
+[ungrouped]
+bastion exoscale_id=6d5c4b3a-2f1e-4d0c-8b9a-7f6e5d4c3b2a exoscale_zone=ch-gva-2
+`, renderInventoryINI(hosts))
+
+	actual, err := renderInventoryJSON(hosts)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "_meta": {
+    "hostvars": {
+      "bastion": {
+        "exoscale_id": "6d5c4b3a-2f1e-4d0c-8b9a-7f6e5d4c3b2a",
+        "exoscale_labels": {},
+        "exoscale_zone": "ch-gva-2"
+      },
+      "web-1": {
+        "ansible_host": "192.0.2.1",
+        "exoscale_id": "0b6a5c1e-3d2f-4e8a-9c7b-1a2b3c4d5e6f",
+        "exoscale_labels": {"app": "web"},
+        "exoscale_zone": "ch-gva-2"
+      },
+      "web-2": {
+        "ansible_host": "192.0.2.2",
+        "exoscale_id": "f0c7dc24-0d5e-4d4c-9b5f-7c8d5b6f6a1e",
+        "exoscale_labels": {"app": "web"},
+        "exoscale_zone": "ch-gva-2"
+      }
+    }
+  },
+  "all": {"children": ["pool_web", "ungrouped"]},
+  "ungrouped": {"hosts": ["bastion"]},
+  "pool_web": {"hosts": ["web-1", "web-2"]}
+}`, actual)
+}
+
+func TestInventoryGroupName(t *testing.T) {
+	require.Equal(t, "pool_web", inventoryGroupName("web"))
+	require.Equal(t, "pool_web_prod_1", inventoryGroupName("web-prod.1"))
+	require.Equal(t, "pool_ungrouped", inventoryGroupName("ungrouped"))
+	require.Equal(t, "pool_all", inventoryGroupName("all"))
+	require.Equal(t, "pool_1st", inventoryGroupName("1st"))
+}
+
+func TestUniqueInventoryHostNames(t *testing.T) {
+	hosts := uniqueInventoryHostNames([]inventoryHost{
+		{ID: "f0c7dc24-0d5e-4d4c-9b5f-7c8d5b6f6a1e", Name: "web"},
+		{ID: "0b6a5c1e-3d2f-4e8a-9c7b-1a2b3c4d5e6f", Name: "web"},
+		{ID: "6d5c4b3a-2f1e-4d0c-8b9a-7f6e5d4c3b2a", Name: "bastion"},
+	})
+
+	names := make([]string, len(hosts))
+	for i, host := range hosts {
+		names[i] = host.Name
+	}
+	require.Equal(t, []string{"web-f0c7dc24", "web-0b6a5c1e", "bastion"}, names)
+}
+
+func TestAccDataSourceInventory(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "exoscale_inventory" "test" {
+  zone = "%s"
+  labels = {
+    test = "%s"
+  }
+}`,
+					testZoneName,
+					testAccDataSourceInventoryLabelValue,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceInventoryAttributes(testAttrs{
+						dsInventoryAttrInstances + ".#": validateString("0"),
+						dsInventoryAttrINI:              validateString(""),
+						dsInventoryAttrJSON:             validateString(`{"_meta":{"hostvars":{}},"all":{"children":[]}}`),
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceInventoryAttributes(expected testAttrs) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ds, ok := s.RootModule().Resources["data.exoscale_inventory.test"]
+		if !ok {
+			return errors.New("exoscale_inventory data source not found in the state")
+		}
+
+		return checkResourceAttributes(expected, ds.Primary.Attributes)
+	}
+}
@@ -3,10 +3,15 @@ package exoscale
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
 
+	"github.com/exoscale/egoscale"
+	exov2 "github.com/exoscale/egoscale/v2"
 	exoapi "github.com/exoscale/egoscale/v2/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
@@ -14,6 +19,7 @@ const (
 	dsNLBAttrDescription = "description"
 	dsNLBAttrID          = "id"
 	dsNLBAttrIPAddress   = "ip_address"
+	dsNLBAttrLabels      = "labels"
 	dsNLBAttrName        = "name"
 	dsNLBAttrState       = "state"
 	dsNLBAttrZone        = "zone"
@@ -34,17 +40,29 @@ func dataSourceNLB() *schema.Resource {
 				Type:          schema.TypeString,
 				Description:   "ID of the Network Load Balancer",
 				Optional:      true,
-				ConflictsWith: []string{dsNLBAttrName},
+				ConflictsWith: []string{dsNLBAttrIPAddress, dsNLBAttrName, dsNLBAttrLabels},
 			},
 			dsNLBAttrIPAddress: {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:          schema.TypeString,
+				Description:   "Public IP address of the Network Load Balancer",
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validation.IsIPAddress,
+				ConflictsWith: []string{dsNLBAttrID, dsNLBAttrName, dsNLBAttrLabels},
+			},
+			dsNLBAttrLabels: {
+				Type:          schema.TypeMap,
+				Description:   "Labels of the Network Load Balancer",
+				Optional:      true,
+				Computed:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{dsNLBAttrID, dsNLBAttrIPAddress, dsNLBAttrName},
 			},
 			dsNLBAttrName: {
 				Type:          schema.TypeString,
 				Description:   "Name of the Network Load Balancer",
 				Optional:      true,
-				ConflictsWith: []string{dsNLBAttrID},
+				ConflictsWith: []string{dsNLBAttrID, dsNLBAttrIPAddress, dsNLBAttrLabels},
 			},
 			dsNLBAttrState: {
 				Type:     schema.TypeString,
@@ -70,21 +88,31 @@ func dataSourceNLBRead(ctx context.Context, d *schema.ResourceData, meta interfa
 
 	client := GetComputeClient(meta)
 
-	var x string
+	var (
+		nlb *exov2.NetworkLoadBalancer
+		err error
+	)
+
 	_, byID := d.GetOk(dsNLBAttrID)
 	_, byName := d.GetOk(dsNLBAttrName)
+	ipAddress, byIPAddress := d.GetOk(dsNLBAttrIPAddress)
+	labels, byLabels := d.GetOk(dsNLBAttrLabels)
 	switch {
 	case byID:
-		x = d.Get(dsNLBAttrID).(string)
+		nlb, err = client.FindNetworkLoadBalancer(ctx, zone, d.Get(dsNLBAttrID).(string))
 
 	case byName:
-		x = d.Get(dsNLBAttrName).(string)
+		nlb, err = client.FindNetworkLoadBalancer(ctx, zone, d.Get(dsNLBAttrName).(string))
+
+	case byIPAddress:
+		nlb, err = findNLBByIPAddress(ctx, client, zone, ipAddress.(string))
+
+	case byLabels:
+		nlb, err = findNLBByLabels(ctx, client, zone, labels.(map[string]interface{}))
 
 	default:
-		return diag.FromErr(errors.New("either name or id must be specified"))
+		return diag.FromErr(errors.New("either name, id, ip_address or labels must be specified"))
 	}
-
-	nlb, err := client.FindNetworkLoadBalancer(ctx, zone, x)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -115,5 +143,58 @@ func dataSourceNLBRead(ctx context.Context, d *schema.ResourceData, meta interfa
 		return diag.FromErr(err)
 	}
 
+	if nlb.Labels != nil {
+		if err := d.Set(dsNLBAttrLabels, *nlb.Labels); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return nil
 }
+
+// findNLBByLabels returns the only Network Load Balancer of the zone matching
+// all the specified labels.
+func findNLBByLabels(
+	ctx context.Context,
+	client *egoscale.Client,
+	zone string,
+	labels map[string]interface{},
+) (*exov2.NetworkLoadBalancer, error) {
+	nlbs, err := client.ListNetworkLoadBalancers(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	i, err := findByLabels("Network Load Balancer", labels, len(nlbs), func(i int) *map[string]string {
+		return nlbs[i].Labels
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nlbs[i], nil
+}
+
+// findNLBByIPAddress returns the Network Load Balancer of the zone having the
+// specified public IP address.
+func findNLBByIPAddress(
+	ctx context.Context,
+	client *egoscale.Client,
+	zone string,
+	ipAddress string,
+) (*exov2.NetworkLoadBalancer, error) {
+	ip := net.ParseIP(ipAddress)
+
+	nlbs, err := client.ListNetworkLoadBalancers(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, nlb := range nlbs {
+		if nlb.IPAddress != nil && nlb.IPAddress.Equal(ip) {
+			return nlb, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no Network Load Balancer found with IP address %s", ipAddress)
+}
@@ -0,0 +1,160 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	dsNLBServiceListAttrNLBID                 = "nlb_id"
+	dsNLBServiceListAttrNLBName               = "nlb_name"
+	dsNLBServiceListAttrServices              = "services"
+	dsNLBServiceListAttrServiceDescription    = "description"
+	dsNLBServiceListAttrServiceID             = "id"
+	dsNLBServiceListAttrServiceInstancePoolID = "instance_pool_id"
+	dsNLBServiceListAttrServiceName           = "name"
+	dsNLBServiceListAttrServicePort           = "port"
+	dsNLBServiceListAttrServiceProtocol       = "protocol"
+	dsNLBServiceListAttrServiceState          = "state"
+	dsNLBServiceListAttrServiceStrategy       = "strategy"
+	dsNLBServiceListAttrServiceTargetPort     = "target_port"
+	dsNLBServiceListAttrZone                  = "zone"
+)
+
+func dataSourceNLBServiceList() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			dsNLBServiceListAttrNLBID: {
+				Type:          schema.TypeString,
+				Description:   "ID of the Network Load Balancer",
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{dsNLBServiceListAttrNLBName},
+			},
+			dsNLBServiceListAttrNLBName: {
+				Type:          schema.TypeString,
+				Description:   "Name of the Network Load Balancer",
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{dsNLBServiceListAttrNLBID},
+			},
+			dsNLBServiceListAttrServices: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						dsNLBServiceListAttrServiceDescription: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsNLBServiceListAttrServiceID: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsNLBServiceListAttrServiceInstancePoolID: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsNLBServiceListAttrServiceName: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsNLBServiceListAttrServicePort: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						dsNLBServiceListAttrServiceProtocol: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsNLBServiceListAttrServiceState: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsNLBServiceListAttrServiceStrategy: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsNLBServiceListAttrServiceTargetPort: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			dsNLBServiceListAttrZone: {
+				Type:        schema.TypeString,
+				Description: "Zone of the Network Load Balancer",
+				Required:    true,
+			},
+		},
+
+		ReadContext: dataSourceNLBServiceListRead,
+	}
+}
+
+func dataSourceNLBServiceListRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	zone := d.Get(dsNLBServiceListAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	var x string
+	if v, ok := d.GetOk(dsNLBServiceListAttrNLBID); ok {
+		x = v.(string)
+	} else if v, ok := d.GetOk(dsNLBServiceListAttrNLBName); ok {
+		x = v.(string)
+	} else {
+		return diag.FromErr(errors.New("either nlb_name or nlb_id must be specified"))
+	}
+
+	nlb, err := client.FindNetworkLoadBalancer(ctx, zone, x)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(*nlb.ID)
+
+	if err := d.Set(dsNLBServiceListAttrNLBID, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsNLBServiceListAttrNLBName, defaultString(nlb.Name, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	services := make([]map[string]interface{}, 0, len(nlb.Services))
+	for _, service := range nlb.Services {
+		var port, targetPort int
+		if service.Port != nil {
+			port = int(*service.Port)
+		}
+		if service.TargetPort != nil {
+			targetPort = int(*service.TargetPort)
+		}
+
+		services = append(services, map[string]interface{}{
+			dsNLBServiceListAttrServiceDescription:    defaultString(service.Description, ""),
+			dsNLBServiceListAttrServiceID:             defaultString(service.ID, ""),
+			dsNLBServiceListAttrServiceInstancePoolID: defaultString(service.InstancePoolID, ""),
+			dsNLBServiceListAttrServiceName:           defaultString(service.Name, ""),
+			dsNLBServiceListAttrServicePort:           port,
+			dsNLBServiceListAttrServiceProtocol:       defaultString(service.Protocol, ""),
+			dsNLBServiceListAttrServiceState:          defaultString(service.State, ""),
+			dsNLBServiceListAttrServiceStrategy:       defaultString(service.Strategy, ""),
+			dsNLBServiceListAttrServiceTargetPort:     targetPort,
+		})
+	}
+	if err := d.Set(dsNLBServiceListAttrServices, services); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
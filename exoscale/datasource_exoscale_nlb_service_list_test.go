@@ -0,0 +1,134 @@
+package exoscale
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var (
+	testAccDataSourceNLBServiceListZone             = testZoneName
+	testAccDataSourceNLBServiceListInstancePoolName = acctest.RandomWithPrefix(testPrefix)
+	testAccDataSourceNLBServiceListNLBName          = acctest.RandomWithPrefix(testPrefix)
+	testAccDataSourceNLBServiceListServiceName      = acctest.RandomWithPrefix(testPrefix)
+	testAccDataSourceNLBServiceListResourceConfig   = fmt.Sprintf(`
+locals {
+  zone = "%s"
+}
+
+resource "exoscale_instance_pool" "test" {
+  zone = local.zone
+  name = "%s"
+  template_id = "%s"
+  service_offering = "small"
+  size = 1
+  disk_size = 10
+
+  timeouts {
+    delete = "10m"
+  }
+}
+
+resource "exoscale_nlb" "test" {
+  zone = local.zone
+  name = "%s"
+
+  timeouts {
+    delete = "10m"
+  }
+}
+
+resource "exoscale_nlb_service" "test" {
+  zone = local.zone
+  name = "%s"
+  nlb_id = exoscale_nlb.test.id
+  instance_pool_id = exoscale_instance_pool.test.id
+  port = 80
+  target_port = 8080
+
+  healthcheck {
+    port = 8080
+  }
+
+  timeouts {
+    delete = "10m"
+  }
+}`,
+		testAccDataSourceNLBServiceListZone,
+		testAccDataSourceNLBServiceListInstancePoolName,
+		testInstanceTemplateID,
+		testAccDataSourceNLBServiceListNLBName,
+		testAccDataSourceNLBServiceListServiceName,
+	)
+)
+
+func TestAccDataSourceNLBServiceList(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`%s
+data "exoscale_nlb_service_list" "test" {
+  zone = local.zone
+}`,
+					testAccDataSourceNLBServiceListResourceConfig),
+				ExpectError: regexp.MustCompile("either nlb_name or nlb_id must be specified"),
+			},
+			{
+				Config: fmt.Sprintf(`%s
+data "exoscale_nlb_service_list" "by-id" {
+  zone = local.zone
+  nlb_id = exoscale_nlb_service.test.nlb_id
+}`,
+					testAccDataSourceNLBServiceListResourceConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceNLBServiceListAttributes("data.exoscale_nlb_service_list.by-id", testAttrs{
+						dsNLBServiceListAttrNLBID:                       validation.ToDiagFunc(validation.IsUUID),
+						dsNLBServiceListAttrNLBName:                     validateString(testAccDataSourceNLBServiceListNLBName),
+						dsNLBServiceListAttrServices + ".#":             validateString("1"),
+						dsNLBServiceListAttrServices + ".0.id":          validation.ToDiagFunc(validation.IsUUID),
+						dsNLBServiceListAttrServices + ".0.name":        validateString(testAccDataSourceNLBServiceListServiceName),
+						dsNLBServiceListAttrServices + ".0.port":        validateString("80"),
+						dsNLBServiceListAttrServices + ".0.target_port": validateString("8080"),
+					}),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`%s
+data "exoscale_nlb_service_list" "by-name" {
+  zone = local.zone
+  nlb_name = exoscale_nlb.test.name
+
+  depends_on = [exoscale_nlb_service.test]
+}`,
+					testAccDataSourceNLBServiceListResourceConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceNLBServiceListAttributes("data.exoscale_nlb_service_list.by-name", testAttrs{
+						dsNLBServiceListAttrNLBID:                validation.ToDiagFunc(validation.IsUUID),
+						dsNLBServiceListAttrNLBName:              validateString(testAccDataSourceNLBServiceListNLBName),
+						dsNLBServiceListAttrServices + ".#":      validateString("1"),
+						dsNLBServiceListAttrServices + ".0.name": validateString(testAccDataSourceNLBServiceListServiceName),
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceNLBServiceListAttributes(r string, expected testAttrs) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ds, ok := s.RootModule().Resources[r]
+		if !ok {
+			return errors.New("data source not found in the state")
+		}
+
+		return checkResourceAttributes(expected, ds.Primary.Attributes)
+	}
+}
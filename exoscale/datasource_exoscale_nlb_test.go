@@ -16,15 +16,20 @@ var (
 	testAccDataSourceNLBZone           = testZoneName
 	testAccDataSourceNLBName           = acctest.RandomWithPrefix(testPrefix)
 	testAccDataSourceNLBDescription    = acctest.RandString(10)
+	testAccDataSourceNLBLabelValue     = acctest.RandomWithPrefix(testPrefix)
 	testAccDataSourceNLBResourceConfig = fmt.Sprintf(`
 resource "exoscale_nlb" "test" {
   zone        = "%s"
   name        = "%s"
   description = "%s"
+  labels = {
+    test = "%s"
+  }
 }`,
 		testAccDataSourceNLBZone,
 		testAccDataSourceNLBName,
 		testAccDataSourceNLBDescription,
+		testAccDataSourceNLBLabelValue,
 	)
 )
 
@@ -39,7 +44,7 @@ data "exoscale_nlb" "test" {
   zone = exoscale_nlb.test.zone
 }`,
 					testAccDataSourceNLBResourceConfig),
-				ExpectError: regexp.MustCompile("either name or id must be specified"),
+				ExpectError: regexp.MustCompile("either name, id, ip_address or labels must be specified"),
 			},
 			{
 				Config: fmt.Sprintf(`%s
@@ -79,6 +84,37 @@ data "exoscale_nlb" "by-name" {
 					}),
 				),
 			},
+			{
+				Config: fmt.Sprintf(`%s
+data "exoscale_nlb" "by-ip-address" {
+  zone = exoscale_nlb.test.zone
+  ip_address = exoscale_nlb.test.ip_address
+}`,
+					testAccDataSourceNLBResourceConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceNLBAttributes("data.exoscale_nlb.by-ip-address", testAttrs{
+						dsNLBAttrID:        validation.ToDiagFunc(validation.IsUUID),
+						dsNLBAttrIPAddress: validation.ToDiagFunc(validation.IsIPv4Address),
+						dsNLBAttrName:      validateString(testAccDataSourceNLBName),
+					}),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`%s
+data "exoscale_nlb" "by-labels" {
+  zone = exoscale_nlb.test.zone
+  labels = exoscale_nlb.test.labels
+}`,
+					testAccDataSourceNLBResourceConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceNLBAttributes("data.exoscale_nlb.by-labels", testAttrs{
+						dsNLBAttrID:               validation.ToDiagFunc(validation.IsUUID),
+						dsNLBAttrLabels + ".%":    validateString("1"),
+						dsNLBAttrLabels + ".test": validateString(testAccDataSourceNLBLabelValue),
+						dsNLBAttrName:             validateString(testAccDataSourceNLBName),
+					}),
+				),
+			},
 		},
 	})
 }
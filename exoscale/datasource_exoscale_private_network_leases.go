@@ -0,0 +1,113 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	dsPrivateNetworkLeasesAttrID              = "id"
+	dsPrivateNetworkLeasesAttrLeases          = "leases"
+	dsPrivateNetworkLeasesAttrLeaseInstanceID = "instance_id"
+	dsPrivateNetworkLeasesAttrLeaseIPAddress  = "ip_address"
+	dsPrivateNetworkLeasesAttrName            = "name"
+	dsPrivateNetworkLeasesAttrZone            = "zone"
+)
+
+func dataSourcePrivateNetworkLeases() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			dsPrivateNetworkLeasesAttrID: {
+				Type:          schema.TypeString,
+				Description:   "ID of the Private Network",
+				Optional:      true,
+				ConflictsWith: []string{dsPrivateNetworkLeasesAttrName},
+			},
+			dsPrivateNetworkLeasesAttrLeases: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						dsPrivateNetworkLeasesAttrLeaseInstanceID: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						dsPrivateNetworkLeasesAttrLeaseIPAddress: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			dsPrivateNetworkLeasesAttrName: {
+				Type:          schema.TypeString,
+				Description:   "Name of the Private Network",
+				Optional:      true,
+				ConflictsWith: []string{dsPrivateNetworkLeasesAttrID},
+			},
+			dsPrivateNetworkLeasesAttrZone: {
+				Type:        schema.TypeString,
+				Description: "Zone of the Private Network",
+				Required:    true,
+			},
+		},
+
+		ReadContext: dataSourcePrivateNetworkLeasesRead,
+	}
+}
+
+func dataSourcePrivateNetworkLeasesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	zone := d.Get(dsPrivateNetworkLeasesAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	var x string
+	_, byID := d.GetOk(dsPrivateNetworkLeasesAttrID)
+	_, byName := d.GetOk(dsPrivateNetworkLeasesAttrName)
+	switch {
+	case byID:
+		x = d.Get(dsPrivateNetworkLeasesAttrID).(string)
+
+	case byName:
+		x = d.Get(dsPrivateNetworkLeasesAttrName).(string)
+
+	default:
+		return diag.FromErr(errors.New("either name or id must be specified"))
+	}
+
+	privateNetwork, err := client.FindPrivateNetwork(ctx, zone, x)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(*privateNetwork.ID)
+
+	if err := d.Set(dsPrivateNetworkLeasesAttrID, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsPrivateNetworkLeasesAttrName, defaultString(privateNetwork.Name, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	leases := make([]map[string]interface{}, len(privateNetwork.Leases))
+	for i, lease := range privateNetwork.Leases {
+		leases[i] = map[string]interface{}{
+			dsPrivateNetworkLeasesAttrLeaseInstanceID: defaultString(lease.InstanceID, ""),
+			dsPrivateNetworkLeasesAttrLeaseIPAddress:  lease.IPAddress.String(),
+		}
+	}
+	if err := d.Set(dsPrivateNetworkLeasesAttrLeases, leases); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package exoscale
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var (
+	testAccDataSourcePrivateNetworkLeasesZone        = testZoneName
+	testAccDataSourcePrivateNetworkLeasesNetworkName = acctest.RandomWithPrefix(testPrefix)
+	testAccDataSourcePrivateNetworkLeasesComputeName = acctest.RandomWithPrefix(testPrefix)
+	testAccDataSourcePrivateNetworkLeasesIPAddress   = "10.0.0.1"
+
+	testAccDataSourcePrivateNetworkLeasesResourceConfig = fmt.Sprintf(`
+locals {
+  zone = "%s"
+}
+
+resource "exoscale_compute" "vm" {
+  zone = local.zone
+  display_name = "%s"
+  template_id = "%s"
+  size = "Micro"
+  disk_size = "10"
+}
+
+resource "exoscale_network" "net" {
+  zone = local.zone
+  name = "%s"
+  start_ip = "10.0.0.1"
+  end_ip = "10.0.0.1"
+  netmask = "255.255.255.252"
+}
+
+resource "exoscale_nic" "nic" {
+  compute_id = exoscale_compute.vm.id
+  network_id = exoscale_network.net.id
+  ip_address = "%s"
+}`,
+		testAccDataSourcePrivateNetworkLeasesZone,
+		testAccDataSourcePrivateNetworkLeasesComputeName,
+		testInstanceTemplateID,
+		testAccDataSourcePrivateNetworkLeasesNetworkName,
+		testAccDataSourcePrivateNetworkLeasesIPAddress,
+	)
+)
+
+func TestAccDataSourcePrivateNetworkLeases(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`%s
+data "exoscale_private_network_leases" "test" {
+  zone = local.zone
+}`,
+					testAccDataSourcePrivateNetworkLeasesResourceConfig),
+				ExpectError: regexp.MustCompile("either name or id must be specified"),
+			},
+			{
+				Config: fmt.Sprintf(`%s
+data "exoscale_private_network_leases" "by-id" {
+  zone = local.zone
+  id = exoscale_nic.nic.network_id
+}`,
+					testAccDataSourcePrivateNetworkLeasesResourceConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourcePrivateNetworkLeasesAttributes("data.exoscale_private_network_leases.by-id", testAttrs{
+						dsPrivateNetworkLeasesAttrID:            validation.ToDiagFunc(validation.IsUUID),
+						dsPrivateNetworkLeasesAttrName:          validateString(testAccDataSourcePrivateNetworkLeasesNetworkName),
+						dsPrivateNetworkLeasesAttrLeases + ".#": validateString("1"),
+						dsPrivateNetworkLeasesAttrLeases + ".0." + dsPrivateNetworkLeasesAttrLeaseInstanceID: validation.ToDiagFunc(validation.IsUUID),
+						dsPrivateNetworkLeasesAttrLeases + ".0." + dsPrivateNetworkLeasesAttrLeaseIPAddress:  validateString(testAccDataSourcePrivateNetworkLeasesIPAddress),
+					}),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`%s
+data "exoscale_private_network_leases" "by-name" {
+  zone = local.zone
+  name = exoscale_network.net.name
+
+  depends_on = [exoscale_nic.nic]
+}`,
+					testAccDataSourcePrivateNetworkLeasesResourceConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourcePrivateNetworkLeasesAttributes("data.exoscale_private_network_leases.by-name", testAttrs{
+						dsPrivateNetworkLeasesAttrID:            validation.ToDiagFunc(validation.IsUUID),
+						dsPrivateNetworkLeasesAttrName:          validateString(testAccDataSourcePrivateNetworkLeasesNetworkName),
+						dsPrivateNetworkLeasesAttrLeases + ".#": validateString("1"),
+						dsPrivateNetworkLeasesAttrLeases + ".0." + dsPrivateNetworkLeasesAttrLeaseInstanceID: validation.ToDiagFunc(validation.IsUUID),
+						dsPrivateNetworkLeasesAttrLeases + ".0." + dsPrivateNetworkLeasesAttrLeaseIPAddress:  validateString(testAccDataSourcePrivateNetworkLeasesIPAddress),
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourcePrivateNetworkLeasesAttributes(r string, expected testAttrs) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ds, ok := s.RootModule().Resources[r]
+		if !ok {
+			return errors.New("data source not found in the state")
+		}
+
+		return checkResourceAttributes(expected, ds.Primary.Attributes)
+	}
+}
@@ -0,0 +1,196 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	dsTemplateAttrBootMode    = "boot_mode"
+	dsTemplateAttrBuild       = "build"
+	dsTemplateAttrCreatedAt   = "created_at"
+	dsTemplateAttrDefaultUser = "default_user"
+	dsTemplateAttrDescription = "description"
+	dsTemplateAttrFamily      = "family"
+	dsTemplateAttrID          = "id"
+	dsTemplateAttrName        = "name"
+	dsTemplateAttrNameRegex   = "name_regex"
+	dsTemplateAttrSize        = "size"
+	dsTemplateAttrVersion     = "version"
+	dsTemplateAttrVisibility  = "visibility"
+	dsTemplateAttrZone        = "zone"
+)
+
+func dataSourceTemplate() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			dsTemplateAttrBootMode: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			dsTemplateAttrBuild: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			dsTemplateAttrCreatedAt: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			dsTemplateAttrDefaultUser: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			dsTemplateAttrDescription: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			dsTemplateAttrFamily: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			dsTemplateAttrID: {
+				Type:          schema.TypeString,
+				Description:   "ID of the template",
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{dsTemplateAttrName, dsTemplateAttrNameRegex},
+			},
+			dsTemplateAttrName: {
+				Type:          schema.TypeString,
+				Description:   "Name of the template",
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{dsTemplateAttrID, dsTemplateAttrNameRegex},
+			},
+			dsTemplateAttrNameRegex: {
+				Type:          schema.TypeString,
+				Description:   "Regular expression the name of the template must match",
+				Optional:      true,
+				ValidateFunc:  validation.StringIsValidRegExp,
+				ConflictsWith: []string{dsTemplateAttrID, dsTemplateAttrName},
+			},
+			dsTemplateAttrSize: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			dsTemplateAttrVersion: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			dsTemplateAttrVisibility: {
+				Type:         schema.TypeString,
+				Description:  "Visibility of the template",
+				Optional:     true,
+				Default:      "public",
+				ValidateFunc: validation.StringInSlice([]string{"public", "private"}, false),
+			},
+			dsTemplateAttrZone: {
+				Type:        schema.TypeString,
+				Description: "Zone of the template",
+				Required:    true,
+			},
+		},
+
+		ReadContext: dataSourceTemplateRead,
+	}
+}
+
+func dataSourceTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	zone := d.Get(dsTemplateAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	var (
+		template *exov2.Template
+		err      error
+	)
+
+	if v, ok := d.GetOk(dsTemplateAttrID); ok {
+		if template, err = client.GetTemplate(ctx, zone, v.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		var filter *regexp.Regexp
+		switch {
+		case d.Get(dsTemplateAttrName).(string) != "":
+			filter = regexp.MustCompile("^" + regexp.QuoteMeta(d.Get(dsTemplateAttrName).(string)) + "$")
+		case d.Get(dsTemplateAttrNameRegex).(string) != "":
+			filter = regexp.MustCompile(d.Get(dsTemplateAttrNameRegex).(string))
+		default:
+			return diag.FromErr(errors.New("either id, name or name_regex must be specified"))
+		}
+
+		templates, err := client.ListTemplates(ctx, zone, d.Get(dsTemplateAttrVisibility).(string), "")
+		if err != nil {
+			return diag.Errorf("unable to list templates: %s", err)
+		}
+
+		if template, err = findLatestTemplate(templates, filter); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(*template.ID)
+
+	var createdAt string
+	if template.CreatedAt != nil {
+		createdAt = template.CreatedAt.String()
+	}
+
+	if err := d.Set(dsTemplateAttrBootMode, defaultString(template.BootMode, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsTemplateAttrBuild, defaultString(template.Build, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsTemplateAttrCreatedAt, createdAt); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsTemplateAttrDefaultUser, defaultString(template.DefaultUser, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsTemplateAttrDescription, defaultString(template.Description, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsTemplateAttrFamily, defaultString(template.Family, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsTemplateAttrID, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsTemplateAttrName, defaultString(template.Name, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsTemplateAttrSize, defaultInt64(template.Size, 0)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsTemplateAttrVersion, defaultString(template.Version, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsTemplateAttrVisibility, defaultString(template.Visibility, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
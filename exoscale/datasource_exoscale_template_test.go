@@ -0,0 +1,89 @@
+package exoscale
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDataSourceTemplate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "exoscale_template" "test" {
+  zone = "%s"
+}`,
+					testZoneName),
+				ExpectError: regexp.MustCompile("either id, name or name_regex must be specified"),
+			},
+			{
+				Config: fmt.Sprintf(`
+data "exoscale_template" "test" {
+  zone = "%s"
+  name = "%s"
+}`,
+					testZoneName,
+					testInstanceTemplateName,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceTemplateAttributes("data.exoscale_template.test", testAttrs{
+						dsTemplateAttrDefaultUser: validateString(testInstanceTemplateUsername),
+						dsTemplateAttrID:          validateString(testInstanceTemplateID),
+						dsTemplateAttrName:        validateString(testInstanceTemplateName),
+						dsTemplateAttrVisibility:  validateString("public"),
+					}),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+data "exoscale_template" "test" {
+  zone = "%s"
+  name_regex = "^%s$"
+}`,
+					testZoneName,
+					testInstanceTemplateName,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceTemplateAttributes("data.exoscale_template.test", testAttrs{
+						dsTemplateAttrID:   validateString(testInstanceTemplateID),
+						dsTemplateAttrName: validateString(testInstanceTemplateName),
+					}),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+data "exoscale_template" "test" {
+  zone = "%s"
+  id = "%s"
+}`,
+					testZoneName,
+					testInstanceTemplateID,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceTemplateAttributes("data.exoscale_template.test", testAttrs{
+						dsTemplateAttrID:   validateString(testInstanceTemplateID),
+						dsTemplateAttrName: validateString(testInstanceTemplateName),
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceTemplateAttributes(r string, expected testAttrs) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ds, ok := s.RootModule().Resources[r]
+		if !ok {
+			return errors.New("data source not found in the state")
+		}
+
+		return checkResourceAttributes(expected, ds.Primary.Attributes)
+	}
+}
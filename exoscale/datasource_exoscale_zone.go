@@ -0,0 +1,164 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	dsZoneAttrDatabaseTypes    = "database_types"
+	dsZoneAttrGPUInstanceTypes = "gpu_instance_types"
+	dsZoneAttrInstanceTypes    = "instance_types"
+	dsZoneAttrName             = "name"
+	dsZoneAttrSKS              = "sks"
+	dsZoneAttrSKSVersions      = "sks_versions"
+)
+
+func dataSourceZone() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			dsZoneAttrDatabaseTypes: {
+				Type:        schema.TypeList,
+				Description: "Database Service types available in the zone",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			dsZoneAttrGPUInstanceTypes: {
+				Type:        schema.TypeList,
+				Description: "GPU Compute instance types available to the organization in the zone",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			dsZoneAttrInstanceTypes: {
+				Type:        schema.TypeList,
+				Description: "Compute instance types available to the organization in the zone",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			dsZoneAttrName: {
+				Type:        schema.TypeString,
+				Description: "Name of the zone",
+				Required:    true,
+			},
+			dsZoneAttrSKS: {
+				Type:        schema.TypeBool,
+				Description: "Whether SKS is available in the zone",
+				Computed:    true,
+			},
+			dsZoneAttrSKSVersions: {
+				Type:        schema.TypeList,
+				Description: "Kubernetes versions available for SKS clusters in the zone",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+
+		ReadContext: dataSourceZoneRead,
+	}
+}
+
+func dataSourceZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	zone := d.Get(dsZoneAttrName).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	zones, err := client.ListZones(ctx)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to list zones: %w", err))
+	}
+	if !in(zones, zone) {
+		return diag.Errorf("zone %q not found (available zones: %s)", zone, strings.Join(zones, ", "))
+	}
+
+	instanceTypes, err := client.ListInstanceTypes(ctx, zone)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to list instance types: %w", err))
+	}
+
+	instanceTypeNames := make([]string, 0)
+	gpuInstanceTypeNames := make([]string, 0)
+	for _, instanceType := range instanceTypes {
+		if !defaultBool(instanceType.Authorized, true) {
+			continue
+		}
+
+		name := fmt.Sprintf(
+			"%s.%s",
+			strings.ToLower(defaultString(instanceType.Family, "")),
+			strings.ToLower(defaultString(instanceType.Size, "")),
+		)
+		instanceTypeNames = append(instanceTypeNames, name)
+		if defaultInt64(instanceType.GPUs, 0) > 0 {
+			gpuInstanceTypeNames = append(gpuInstanceTypeNames, name)
+		}
+	}
+	sort.Strings(instanceTypeNames)
+	sort.Strings(gpuInstanceTypeNames)
+
+	// Products not deployed in a zone are reported by the API as "not found"
+	// errors, in which case they're considered unavailable rather than failing
+	// the read. Any other error (e.g. authentication, server or network
+	// failure) is reported, as considering the product unavailable would
+	// cause resources depending on it to be planned for destruction.
+	var diags diag.Diagnostics
+
+	sksVersions, err := client.ListSKSClusterVersions(ctx)
+	if err != nil {
+		if !errors.Is(err, exoapi.ErrNotFound) {
+			return diag.FromErr(fmt.Errorf("unable to list SKS versions: %w", err))
+		}
+		log.Printf("[DEBUG] SKS not available in zone %s: %s", zone, err)
+		diags = append(diags, unavailableServiceWarning(meta, "SKS", err)...)
+		sksVersions = make([]string, 0)
+	}
+
+	databaseTypeNames := make([]string, 0)
+	databaseTypes, err := client.ListDatabaseServiceTypes(ctx, zone)
+	if err != nil {
+		if !errors.Is(err, exoapi.ErrNotFound) {
+			return diag.FromErr(fmt.Errorf("unable to list Database Service types: %w", err))
+		}
+		log.Printf("[DEBUG] DBaaS not available in zone %s: %s", zone, err)
+		diags = append(diags, unavailableServiceWarning(meta, "DBaaS", err)...)
+	}
+	for _, databaseType := range databaseTypes {
+		databaseTypeNames = append(databaseTypeNames, defaultString(databaseType.Name, ""))
+	}
+	sort.Strings(databaseTypeNames)
+
+	d.SetId(zone)
+
+	if err := d.Set(dsZoneAttrDatabaseTypes, databaseTypeNames); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsZoneAttrGPUInstanceTypes, gpuInstanceTypeNames); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsZoneAttrInstanceTypes, instanceTypeNames); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsZoneAttrSKS, len(sksVersions) > 0); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(dsZoneAttrSKSVersions, sksVersions); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,55 @@
+package exoscale
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDataSourceZone(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "exoscale_zone" "test" {
+  name = "lolnope"
+}`,
+				ExpectError: regexp.MustCompile(`zone "lolnope" not found`),
+			},
+			{
+				Config: fmt.Sprintf(`
+data "exoscale_zone" "test" {
+  name = "%s"
+}`,
+					testZoneName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceZoneAttributes(testAttrs{
+						dsZoneAttrName:                 validateString(testZoneName),
+						dsZoneAttrInstanceTypes + ".#": validation.ToDiagFunc(validation.StringNotInSlice([]string{"0"}, false)),
+						dsZoneAttrDatabaseTypes + ".#": validation.ToDiagFunc(validation.StringNotInSlice([]string{"0"}, false)),
+						dsZoneAttrSKS:                  validateString("true"),
+						dsZoneAttrSKSVersions + ".#":   validation.ToDiagFunc(validation.StringNotInSlice([]string{"0"}, false)),
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceZoneAttributes(expected testAttrs) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ds, ok := s.RootModule().Resources["data.exoscale_zone.test"]
+		if !ok {
+			return errors.New("exoscale_zone data source not found in the state")
+		}
+
+		return checkResourceAttributes(expected, ds.Primary.Attributes)
+	}
+}
@@ -0,0 +1,183 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/exoscale/egoscale"
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const (
+	// DoctorCheckStatusOK reports a successful diagnostic check.
+	DoctorCheckStatusOK = "ok"
+	// DoctorCheckStatusWarning reports a diagnostic check that succeeded with
+	// a potential issue.
+	DoctorCheckStatusWarning = "warning"
+	// DoctorCheckStatusError reports a failed diagnostic check.
+	DoctorCheckStatusError = "error"
+	// DoctorCheckStatusSkipped reports a diagnostic check that couldn't be
+	// performed because of a previous check failure.
+	DoctorCheckStatusSkipped = "skipped"
+
+	// doctorMaxClockSkew is the clock skew above which an error is reported,
+	// as the API rejects requests signed too far from its own time.
+	doctorMaxClockSkew = time.Minute
+)
+
+// DoctorCheck represents the result of a single diagnostic check.
+type DoctorCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// DoctorReport represents the result of the provider diagnostic checks.
+type DoctorReport struct {
+	OK     bool           `json:"ok"`
+	Checks []*DoctorCheck `json:"checks"`
+}
+
+func (r *DoctorReport) add(name, status, detail string) {
+	r.Checks = append(r.Checks, &DoctorCheck{Name: name, Status: status, Detail: detail})
+	if status == DoctorCheckStatusError {
+		r.OK = false
+	}
+}
+
+// Doctor performs diagnostic checks of the provider environment, configured
+// the same way as when run by Terraform without a provider block (i.e. using
+// environment variables and configuration file): API credentials validity,
+// API endpoint reachability, local clock skew and account quota headroom.
+func Doctor(ctx context.Context) *DoctorReport {
+	report := &DoctorReport{OK: true}
+
+	// Credentials are validated separately below, so that their failure
+	// can be told apart from other configuration errors.
+	p := Provider()
+	if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"skip_credentials_validation": true,
+	})); diags.HasError() {
+		report.add("configuration", DoctorCheckStatusError, doctorDiagsDetail(diags))
+		for _, name := range []string{"endpoint", "credentials", "clock_skew", "quota"} {
+			report.add(name, DoctorCheckStatusSkipped, "")
+		}
+		return report
+	}
+	report.add("configuration", DoctorCheckStatusOK, "")
+
+	config := p.Meta().(BaseConfig)
+	client := GetComputeClient(config)
+
+	// Endpoint reachability and clock skew, using an unauthenticated request.
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, config.computeEndpoint, nil)
+	if err != nil {
+		report.add("endpoint", DoctorCheckStatusError, err.Error())
+		report.add("clock_skew", DoctorCheckStatusSkipped, "")
+	} else {
+		start := time.Now()
+		resp, err := cleanhttp.DefaultClient().Do(req)
+		if err != nil {
+			report.add("endpoint", DoctorCheckStatusError, err.Error())
+			report.add("clock_skew", DoctorCheckStatusSkipped, "")
+		} else {
+			resp.Body.Close()
+			report.add("endpoint", DoctorCheckStatusOK, fmt.Sprintf("%s reachable in %s",
+				config.computeEndpoint,
+				time.Since(start).Round(time.Millisecond)))
+
+			skew, err := doctorClockSkew(resp.Header.Get("Date"), time.Now())
+			switch {
+			case err != nil:
+				report.add("clock_skew", DoctorCheckStatusWarning, err.Error())
+			case skew > doctorMaxClockSkew || skew < -doctorMaxClockSkew:
+				report.add("clock_skew", DoctorCheckStatusError, fmt.Sprintf(
+					"local clock is %s off the API clock: API requests are likely to be rejected",
+					skew))
+			default:
+				report.add("clock_skew", DoctorCheckStatusOK, fmt.Sprintf("local clock is %s off the API clock", skew))
+			}
+		}
+	}
+
+	// Credentials validity.
+	if _, err := client.RequestWithContext(ctx, &egoscale.ListZones{}); err != nil {
+		report.add("credentials", DoctorCheckStatusError, err.Error())
+		report.add("quota", DoctorCheckStatusSkipped, "")
+		return report
+	}
+	report.add("credentials", DoctorCheckStatusOK, fmt.Sprintf("API key %s", config.key))
+
+	// Compute instances quota headroom.
+	status, detail := doctorQuota(ctx, client)
+	report.add("quota", status, detail)
+
+	return report
+}
+
+// doctorQuota returns the status and detail of the Compute instances quota
+// headroom diagnostic check.
+func doctorQuota(ctx context.Context, client *egoscale.Client) (string, string) {
+	resp, err := client.RequestWithContext(ctx, &egoscale.ListResourceLimits{
+		ResourceTypeName: string(egoscale.VirtualMachineTypeName),
+	})
+	if err != nil {
+		return DoctorCheckStatusError, fmt.Sprintf("unable to retrieve resource limits: %s", err)
+	}
+
+	limits := resp.(*egoscale.ListResourceLimitsResponse).ResourceLimit
+	if len(limits) == 0 || limits[0].Max < 0 {
+		return DoctorCheckStatusOK, "no Compute instances limit"
+	}
+	limit := limits[0].Max
+
+	resp, err = client.RequestWithContext(ctx, &egoscale.ListVirtualMachines{})
+	if err != nil {
+		return DoctorCheckStatusError, fmt.Sprintf("unable to list Compute instances: %s", err)
+	}
+	used := int64(resp.(*egoscale.ListVirtualMachinesResponse).Count)
+
+	detail := fmt.Sprintf("%d/%d Compute instances used", used, limit)
+	if used >= limit {
+		return DoctorCheckStatusWarning, detail + ": no Compute instance can be created"
+	}
+
+	return DoctorCheckStatusOK, detail
+}
+
+// doctorClockSkew returns the difference between the local time now and the
+// remote time reported in an HTTP Date header value.
+func doctorClockSkew(date string, now time.Time) (time.Duration, error) {
+	if date == "" {
+		return 0, fmt.Errorf("API response has no Date header")
+	}
+
+	remote, err := http.ParseTime(date)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse API response Date header: %s", err)
+	}
+
+	// The Date header has a 1-second resolution.
+	return now.Sub(remote).Round(time.Second), nil
+}
+
+func doctorDiagsDetail(diags diag.Diagnostics) string {
+	details := make([]string, 0, len(diags))
+	for _, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+		detail := d.Summary
+		if d.Detail != "" {
+			detail += ": " + d.Detail
+		}
+		details = append(details, detail)
+	}
+
+	return strings.Join(details, "; ")
+}
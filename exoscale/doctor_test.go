@@ -0,0 +1,50 @@
+package exoscale
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_doctorClockSkew(t *testing.T) {
+	now := time.Date(2021, 9, 1, 12, 0, 30, 500, time.UTC)
+
+	skew, err := doctorClockSkew("Wed, 01 Sep 2021 12:00:00 GMT", now)
+	require.NoError(t, err)
+	require.Equal(t, 30*time.Second, skew)
+
+	skew, err = doctorClockSkew("Wed, 01 Sep 2021 12:02:30 GMT", now)
+	require.NoError(t, err)
+	require.Equal(t, -2*time.Minute, skew)
+
+	_, err = doctorClockSkew("", now)
+	require.Error(t, err)
+
+	_, err = doctorClockSkew("lolnope", now)
+	require.Error(t, err)
+}
+
+func Test_doctorDiagsDetail(t *testing.T) {
+	require.Equal(t,
+		"no API key: set EXOSCALE_API_KEY; no API secret",
+		doctorDiagsDetail(diag.Diagnostics{
+			{Severity: diag.Error, Summary: "no API key", Detail: "set EXOSCALE_API_KEY"},
+			{Severity: diag.Warning, Summary: "ignored"},
+			{Severity: diag.Error, Summary: "no API secret"},
+		}))
+}
+
+func TestDoctorReport(t *testing.T) {
+	report := &DoctorReport{OK: true}
+
+	report.add("a", DoctorCheckStatusOK, "")
+	report.add("b", DoctorCheckStatusWarning, "")
+	report.add("c", DoctorCheckStatusSkipped, "")
+	require.True(t, report.OK)
+
+	report.add("d", DoctorCheckStatusError, "")
+	require.False(t, report.OK)
+	require.Len(t, report.Checks, 4)
+}
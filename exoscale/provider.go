@@ -2,20 +2,25 @@ package exoscale
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
+	"log"
 	"os"
 	"os/user"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
 	"gopkg.in/ini.v1"
 
 	"github.com/exoscale/egoscale"
+	exov2 "github.com/exoscale/egoscale/v2"
 	exoapi "github.com/exoscale/egoscale/v2/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	tfmeta "github.com/hashicorp/terraform-plugin-sdk/v2/meta"
 
 	"github.com/exoscale/terraform-provider-exoscale/version"
@@ -34,6 +39,35 @@ func init() {
 		egoscale.UserAgent)
 }
 
+var (
+	providerKeyEnvVars = []string{
+		"EXOSCALE_KEY",
+		"EXOSCALE_API_KEY",
+		"CLOUDSTACK_KEY",
+		"CLOUDSTACK_API_KEY",
+	}
+
+	providerSecretEnvVars = []string{
+		"EXOSCALE_SECRET",
+		"EXOSCALE_SECRET_KEY",
+		"EXOSCALE_API_SECRET",
+		"CLOUDSTACK_SECRET",
+		"CLOUDSTACK_SECRET_KEY",
+	}
+
+	// As for all settings supporting several environment variables, the
+	// first variable set in the list takes precedence over the others.
+	providerTimeoutEnvVars = []string{
+		"EXOSCALE_API_TIMEOUT",
+		"EXOSCALE_TIMEOUT",
+	}
+
+	providerTraceEnvVars = []string{
+		"EXOSCALE_API_TRACE",
+		"EXOSCALE_TRACE",
+	}
+)
+
 // Provider returns an Exoscale Provider.
 func Provider() *schema.Provider {
 	return &schema.Provider{
@@ -42,12 +76,7 @@ func Provider() *schema.Provider {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "Exoscale API key",
-				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
-					"EXOSCALE_KEY",
-					"EXOSCALE_API_KEY",
-					"CLOUDSTACK_KEY",
-					"CLOUDSTACK_API_KEY",
-				}, nil),
+				DefaultFunc: schema.MultiEnvDefaultFunc(providerKeyEnvVars, nil),
 			},
 			"token": {
 				Type:       schema.TypeString,
@@ -59,13 +88,7 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				Sensitive:   true,
 				Description: "Exoscale API secret",
-				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
-					"EXOSCALE_SECRET",
-					"EXOSCALE_SECRET_KEY",
-					"EXOSCALE_API_SECRET",
-					"CLOUDSTACK_SECRET",
-					"CLOUDSTACK_SECRET_KEY",
-				}, nil),
+				DefaultFunc: schema.MultiEnvDefaultFunc(providerSecretEnvVars, nil),
 			},
 			"config": {
 				Type:        schema.TypeString,
@@ -122,7 +145,7 @@ func Provider() *schema.Provider {
 				Description: fmt.Sprintf(
 					"Timeout in seconds for waiting on compute resources to become available (by default: %.0f)",
 					defaultTimeout.Seconds()),
-				DefaultFunc: schema.EnvDefaultFunc("EXOSCALE_TIMEOUT", defaultTimeout.Seconds()),
+				DefaultFunc: schema.MultiEnvDefaultFunc(providerTimeoutEnvVars, defaultTimeout.Seconds()),
 			},
 			"gzip_user_data": {
 				Type:     schema.TypeBool,
@@ -132,51 +155,165 @@ func Provider() *schema.Provider {
 					defaultGzipUserData),
 				DefaultFunc: schema.EnvDefaultFunc("EXOSCALE_GZIP_USER_DATA", defaultGzipUserData),
 			},
+			"forbid_plaintext_credentials": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Refuse to run if the API key or secret are specified in the provider configuration " +
+					"instead of environment variables or configuration file (by default: false)",
+				DefaultFunc: schema.EnvDefaultFunc("EXOSCALE_FORBID_PLAINTEXT_CREDENTIALS", false),
+			},
+			"skip_credentials_validation": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Skip the validation of the API credentials performed when configuring the provider " +
+					"(by default: false)",
+				DefaultFunc: schema.EnvDefaultFunc("EXOSCALE_SKIP_CREDENTIALS_VALIDATION", false),
+			},
+			"tolerate_unavailable_services": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Have list data sources return empty results with a warning instead of failing " +
+					"when an API service is not available in the environment (by default: false)",
+				DefaultFunc: schema.EnvDefaultFunc("EXOSCALE_TOLERATE_UNAVAILABLE_SERVICES", false),
+			},
+			"user_agent_extra": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Product token(s) to append to the User-Agent advertised in API requests " +
+					"(e.g. \"my-module/1.2.3\")",
+				DefaultFunc:      schema.EnvDefaultFunc("EXOSCALE_USER_AGENT_EXTRA", nil),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringDoesNotContainAny("\r\n")),
+			},
 			"delay": {
 				Type:       schema.TypeInt,
 				Optional:   true,
 				Deprecated: "Does nothing",
 			},
+			"scoped_key": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Description: "Mint a restricted API key using the configured credentials, " +
+					"and use it for the duration of the run before revoking it",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Description: fmt.Sprintf(
+								"Name of the minted API key, appended to the %q prefix",
+								scopedKeyNamePrefix),
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(
+								regexp.MustCompile(`^[a-zA-Z0-9_-]*$`),
+								"must only contain letters, digits, dashes and underscores",
+							)),
+						},
+						"operations": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							MinItems:    1,
+							Set:         schema.HashString,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "List of API operations the minted API key is allowed to perform",
+						},
+						"resources": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Set:         schema.HashString,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "List of resources the minted API key is restricted to",
+						},
+						"max_age": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      int(defaultScopedKeyMaxAge.Seconds()),
+							ValidateFunc: validation.IntAtLeast(60),
+							Description: fmt.Sprintf(
+								"Age in seconds after which previously minted API keys which haven't been "+
+									"revoked are revoked when configuring the provider (by default: %d)",
+								int(defaultScopedKeyMaxAge.Seconds())),
+						},
+					},
+				},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"exoscale_affinity":          dataSourceAffinity(),
-			"exoscale_compute":           dataSourceCompute(),
-			"exoscale_compute_ipaddress": dataSourceComputeIPAddress(),
-			"exoscale_compute_template":  dataSourceComputeTemplate(),
-			"exoscale_domain":            dataSourceDomain(),
-			"exoscale_domain_record":     dataSourceDomainRecord(),
-			"exoscale_network":           dataSourceNetwork(),
-			"exoscale_nlb":               dataSourceNLB(),
-			"exoscale_security_group":    dataSourceSecurityGroup(),
+			"exoscale_affinity":               dataSourceAffinity(),
+			"exoscale_anti_affinity_group":    dataSourceAntiAffinityGroup(),
+			"exoscale_compute":                dataSourceCompute(),
+			"exoscale_compute_instance_list":  dataSourceComputeInstanceList(),
+			"exoscale_compute_ipaddress":      dataSourceComputeIPAddress(),
+			"exoscale_compute_template":       dataSourceComputeTemplate(),
+			"exoscale_database_uri":           dataSourceDatabaseURI(),
+			"exoscale_domain":                 dataSourceDomain(),
+			"exoscale_domain_record":          dataSourceDomainRecord(),
+			"exoscale_instance_pool":          dataSourceInstancePool(),
+			"exoscale_instance_pool_list":     dataSourceInstancePoolList(),
+			"exoscale_instance_types":         dataSourceInstanceTypes(),
+			"exoscale_inventory":              dataSourceInventory(),
+			"exoscale_network":                dataSourceNetwork(),
+			"exoscale_nlb":                    dataSourceNLB(),
+			"exoscale_nlb_service_list":       dataSourceNLBServiceList(),
+			"exoscale_private_network_leases": dataSourcePrivateNetworkLeases(),
+			"exoscale_security_group":         dataSourceSecurityGroup(),
+			"exoscale_template":               dataSourceTemplate(),
+			"exoscale_zone":                   dataSourceZone(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"exoscale_affinity":             resourceAffinity(),
-			"exoscale_compute":              resourceCompute(),
-			"exoscale_database":             resourceDatabase(),
-			"exoscale_domain":               resourceDomain(),
-			"exoscale_domain_record":        resourceDomainRecord(),
-			"exoscale_instance_pool":        resourceInstancePool(),
-			"exoscale_ipaddress":            resourceIPAddress(),
-			"exoscale_network":              resourceNetwork(),
-			"exoscale_nic":                  resourceNIC(),
-			"exoscale_nlb":                  resourceNLB(),
-			"exoscale_nlb_service":          resourceNLBService(),
-			"exoscale_secondary_ipaddress":  resourceSecondaryIPAddress(),
-			"exoscale_security_group":       resourceSecurityGroup(),
-			"exoscale_security_group_rule":  resourceSecurityGroupRule(),
-			"exoscale_security_group_rules": resourceSecurityGroupRules(),
-			"exoscale_sks_cluster":          resourceSKSCluster(),
-			"exoscale_sks_nodepool":         resourceSKSNodepool(),
-			"exoscale_ssh_keypair":          resourceSSHKeypair(),
+			"exoscale_affinity":              resourceAffinity(),
+			"exoscale_anti_affinity_group":   resourceAntiAffinityGroup(),
+			"exoscale_compute":               resourceCompute(),
+			"exoscale_compute_instance":      resourceComputeInstance(),
+			"exoscale_database":              resourceDatabase(),
+			"exoscale_domain":                resourceDomain(),
+			"exoscale_domain_record":         resourceDomainRecord(),
+			"exoscale_domain_records":        resourceDomainRecords(),
+			"exoscale_elastic_ip":            resourceElasticIP(),
+			"exoscale_elastic_ip_attachment": resourceElasticIPAttachment(),
+			"exoscale_instance_pool":         resourceInstancePool(),
+			"exoscale_ipaddress":             resourceIPAddress(),
+			"exoscale_network":               resourceNetwork(),
+			"exoscale_nic":                   resourceNIC(),
+			"exoscale_nlb":                   resourceNLB(),
+			"exoscale_nlb_service":           resourceNLBService(),
+			"exoscale_resource_labels":       resourceResourceLabels(),
+			"exoscale_reverse_dns_set":       resourceReverseDNSSet(),
+			"exoscale_secondary_ipaddress":   resourceSecondaryIPAddress(),
+			"exoscale_security_group":        resourceSecurityGroup(),
+			"exoscale_security_group_rule":   resourceSecurityGroupRule(),
+			"exoscale_security_group_rules":  resourceSecurityGroupRules(),
+			"exoscale_sks_cluster":           resourceSKSCluster(),
+			"exoscale_sks_nodepool":          resourceSKSNodepool(),
+			"exoscale_snapshot":              resourceSnapshot(),
+			"exoscale_ssh_key":               resourceSSHKey(),
+			"exoscale_ssh_keypair":           resourceSSHKeypair(),
+			"exoscale_template":              resourceTemplate(),
 		},
 
 		ConfigureContextFunc: providerConfigure,
 	}
 }
 
-func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+// isInlineProviderCredential reports whether the provider credential value v
+// has been specified in the provider configuration, as opposed to being set
+// from the first non-empty of the environment variables envVars.
+func isInlineProviderCredential(v string, envVars []string) bool {
+	if v == "" {
+		return false
+	}
+
+	for _, envVar := range envVars {
+		if ev := os.Getenv(envVar); ev != "" {
+			return ev != v
+		}
+	}
+
+	return true
+}
+
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	key, keyOK := d.GetOk("key")
@@ -185,6 +322,18 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 	dnsEndpoint := d.Get("dns_endpoint").(string)
 	environment := d.Get("environment").(string)
 
+	if d.Get("forbid_plaintext_credentials").(bool) {
+		_, tokenOK := d.GetOk("token")
+		if tokenOK ||
+			isInlineProviderCredential(d.Get("key").(string), providerKeyEnvVars) ||
+			isInlineProviderCredential(d.Get("secret").(string), providerSecretEnvVars) {
+			return nil, diag.Errorf(
+				"the API key and secret must not be specified in the provider configuration " +
+					"(forbid_plaintext_credentials is enabled): use environment variables or a configuration file instead",
+			)
+		}
+	}
+
 	// deprecation support
 	token, tokenOK := d.GetOk("token")
 	if tokenOK && !keyOK {
@@ -290,11 +439,89 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 		dnsEndpoint:     dnsEndpoint,
 		environment:     environment,
 		gzipUserData:    d.Get("gzip_user_data").(bool),
+		userAgentExtra:  d.Get("user_agent_extra").(string),
+
+		tolerateUnavailableServices: d.Get("tolerate_unavailable_services").(bool),
+	}
+
+	if _, ok := d.GetOk("scoped_key"); ok {
+		revokeExpiredScopedKeys(
+			ctx,
+			baseConfig,
+			time.Duration(d.Get("scoped_key.0.max_age").(int))*time.Second,
+		)
+
+		operations := make([]string, 0)
+		for _, op := range d.Get("scoped_key.0.operations").(*schema.Set).List() {
+			operations = append(operations, op.(string))
+		}
+
+		resources := make([]string, 0)
+		for _, res := range d.Get("scoped_key.0.resources").(*schema.Set).List() {
+			resources = append(resources, res.(string))
+		}
+
+		apiKey, err := mintScopedKey(
+			ctx,
+			baseConfig,
+			d.Get("scoped_key.0.name").(string),
+			operations,
+			resources,
+		)
+		if err != nil {
+			return nil, diag.Errorf("unable to mint scoped API key: %s", err)
+		}
+
+		baseConfig.key = apiKey.Key
+		baseConfig.secret = apiKey.Secret
+	}
+
+	if !d.Get("skip_credentials_validation").(bool) {
+		if err := validateCredentials(ctx, baseConfig); err != nil {
+			return nil, diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Invalid Exoscale API credentials",
+				Detail: fmt.Sprintf(
+					"The Exoscale API rejected the configured credentials (%s): "+
+						"check that the API key and secret are valid and haven't been revoked. "+
+						"This check can be disabled using the skip_credentials_validation setting.",
+					err,
+				),
+			}}
+		}
 	}
 
 	return baseConfig, diags
 }
 
+// validateCredentials performs a lightweight authenticated API call using the
+// provided configuration, and returns an error if the API rejects the
+// credentials. Other errors are ignored, as they don't relate to credentials.
+func validateCredentials(ctx context.Context, config BaseConfig) error {
+	client := GetComputeClient(config)
+
+	if _, err := client.RequestWithContext(ctx, &egoscale.ListZones{}); err != nil {
+		if isUnauthorizedError(err) {
+			return err
+		}
+
+		log.Printf("[WARN] unable to validate API credentials: %s", err)
+	}
+
+	return nil
+}
+
+// isUnauthorizedError returns true if err reports that the API rejected the
+// request credentials.
+func isUnauthorizedError(err error) bool {
+	var r *egoscale.ErrorResponse
+	if errors.As(err, &r) {
+		return r.ErrorCode == egoscale.Unauthorized
+	}
+
+	return false
+}
+
 func getZoneByName(ctx context.Context, client *egoscale.Client, zoneName string) (*egoscale.Zone, error) {
 	zone := &egoscale.Zone{}
 
@@ -313,6 +540,29 @@ func getZoneByName(ctx context.Context, client *egoscale.Client, zoneName string
 	return resp.(*egoscale.Zone), nil
 }
 
+// findInstanceType returns the Compute instance type matching v (either an ID
+// or a "FAMILY.SIZE" name) in the specified zone, reporting explicitly when the
+// type isn't available in the zone or isn't authorized for the organization
+// (e.g. GPU instance types).
+func findInstanceType(ctx context.Context, client *egoscale.Client, zone, v string) (*exov2.InstanceType, error) {
+	instanceType, err := client.FindInstanceType(ctx, zone, v)
+	if err != nil {
+		if errors.Is(err, exoapi.ErrNotFound) {
+			return nil, fmt.Errorf("instance type %q is not available in zone %s", v, zone)
+		}
+		return nil, err
+	}
+
+	if !defaultBool(instanceType.Authorized, true) {
+		return nil, fmt.Errorf(
+			"instance type %q is not authorized for your organization, please contact the Exoscale support",
+			v,
+		)
+	}
+
+	return instanceType, nil
+}
+
 // handleNotFound inspects the CloudStack ErrorCode to guess if the resource is missing
 // and then removes it (unsetting the ID) and succeeds.
 func handleNotFound(d *schema.ResourceData, err error) error {
@@ -330,6 +580,119 @@ func handleNotFound(d *schema.ResourceData, err error) error {
 	return err
 }
 
+// isNotFoundError returns true if the specified error returned by the Exoscale
+// API signals a missing resource.
+func isNotFoundError(err error) bool {
+	if r, ok := err.(*egoscale.ErrorResponse); ok {
+		return r.ErrorCode == egoscale.ParamError
+	}
+
+	return errors.Is(err, egoscale.ErrNotFound) || errors.Is(err, exoapi.ErrNotFound)
+}
+
+// nameConflictError returns an error reporting that a resource of type resType
+// named name already exists, along with guidance on how to import it into the
+// Terraform state using the specified import ID.
+func nameConflictError(resType, name, importID string) error {
+	return fmt.Errorf(
+		"a %s named %q already exists: to manage it with Terraform, "+
+			"import it into the state using `terraform import %s.<NAME> %s`",
+		resType,
+		name,
+		resType,
+		importID,
+	)
+}
+
+const (
+	nameCollisionStrategyFail   = "fail"
+	nameCollisionStrategySuffix = "suffix"
+
+	// nameCollisionMaxAttempts is the maximum number of names tried when
+	// creating a resource with the "suffix" name collision strategy.
+	nameCollisionMaxAttempts = 5
+)
+
+// nameCollisionSuffixRegexp matches the random suffix appended to resource
+// names by the "suffix" name collision strategy.
+var nameCollisionSuffixRegexp = regexp.MustCompile(`-[0-9a-f]{6}$`)
+
+// nameCollisionStrategySchema returns the schema of the name_collision_strategy
+// attribute of resources whose name must be unique in the organization.
+func nameCollisionStrategySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  nameCollisionStrategyFail,
+		Description: "Behavior if the name is already taken on creation: fail, " +
+			"or retry with a random suffix appended to the name (suffix)",
+		ValidateFunc: validation.StringInSlice([]string{
+			nameCollisionStrategyFail,
+			nameCollisionStrategySuffix,
+		}, false),
+	}
+}
+
+// suppressNameCollisionSuffix is a DiffSuppressFunc for the name attribute of
+// resources supporting the "suffix" name collision strategy, suppressing the
+// diff between the configured name and the suffixed name of the resource.
+func suppressNameCollisionSuffix(_, old, new string, d *schema.ResourceData) bool {
+	if d.Get("name_collision_strategy").(string) != nameCollisionStrategySuffix {
+		return false
+	}
+
+	return nameCollisionSuffixRegexp.MatchString(old) && nameCollisionSuffixRegexp.ReplaceAllString(old, "") == new
+}
+
+// createWithNameCollisionStrategy creates a resource of type resType named
+// after the "name" attribute using create. lookup must return the import ID of
+// the existing resource with the specified name, or an empty string if there
+// is none. If the name is already taken, either before creation or by another
+// client racing to create a resource with the same name, the creation fails
+// unless the "suffix" name collision strategy is set, in which case it is
+// retried with a random suffix appended to the name.
+func createWithNameCollisionStrategy(
+	d *schema.ResourceData,
+	resType string,
+	lookup func(name string) (string, error),
+	create func(name string) error,
+) error {
+	name := d.Get("name").(string)
+
+	for attempt := 1; ; attempt++ {
+		importID, err := lookup(name)
+		if err != nil {
+			return err
+		}
+
+		if importID == "" {
+			createErr := create(name)
+			if createErr == nil {
+				return nil
+			}
+
+			// The creation failing due to a name conflict is only detectable by
+			// the resource now existing.
+			if importID, err = lookup(name); err != nil || importID == "" {
+				return createErr
+			}
+		}
+
+		if d.Get("name_collision_strategy").(string) != nameCollisionStrategySuffix ||
+			attempt == nameCollisionMaxAttempts {
+			return nameConflictError(resType, name, importID)
+		}
+
+		suffix := make([]byte, 3)
+		if _, err := rand.Read(suffix); err != nil {
+			return err
+		}
+		name = fmt.Sprintf("%s-%x", d.Get("name").(string), suffix)
+
+		log.Printf("[DEBUG] %s: name already taken, retrying with name %q", resType, name)
+	}
+}
+
 type resourceIDStringer interface {
 	Id() string
 }
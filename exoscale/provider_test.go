@@ -2,12 +2,15 @@ package exoscale
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/exoscale/egoscale"
+	exoapi "github.com/exoscale/egoscale/v2/api"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -260,3 +263,261 @@ func Test_zonedStateContextFunc(t *testing.T) {
 		})
 	}
 }
+
+func Test_createWithNameCollisionStrategy(t *testing.T) {
+	testSchema := map[string]*schema.Schema{
+		"name":                    {Type: schema.TypeString},
+		"name_collision_strategy": nameCollisionStrategySchema(),
+	}
+
+	tests := []struct {
+		name     string
+		strategy string
+		taken    map[string]bool
+		wantName string
+		wantErr  bool
+	}{
+		{
+			name:     "name available",
+			strategy: nameCollisionStrategyFail,
+			wantName: "test",
+		},
+		{
+			name:     "name taken, fail",
+			strategy: nameCollisionStrategyFail,
+			taken:    map[string]bool{"test": true},
+			wantErr:  true,
+		},
+		{
+			name:     "name taken, suffix",
+			strategy: nameCollisionStrategySuffix,
+			taken:    map[string]bool{"test": true},
+			wantName: "test-",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, testSchema, map[string]interface{}{
+				"name":                    "test",
+				"name_collision_strategy": tt.strategy,
+			})
+
+			var created string
+			err := createWithNameCollisionStrategy(
+				d,
+				"test",
+				func(name string) (string, error) {
+					if tt.taken[name] {
+						return name, nil
+					}
+					return "", nil
+				},
+				func(name string) error {
+					created = name
+					return nil
+				},
+			)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("createWithNameCollisionStrategy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !strings.HasPrefix(created, tt.wantName) {
+				t.Errorf("createWithNameCollisionStrategy() created %q, want %q", created, tt.wantName)
+			}
+
+			if tt.strategy == nameCollisionStrategySuffix && created != "" &&
+				!suppressNameCollisionSuffix("name", created, "test", d) {
+				t.Errorf("suppressNameCollisionSuffix() didn't suppress %q", created)
+			}
+		})
+	}
+}
+
+func Test_isNotFoundError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "v1 not found",
+			err:  egoscale.ErrNotFound,
+			want: true,
+		},
+		{
+			name: "v1 wrapped not found",
+			err:  fmt.Errorf("lookup failed: %w", egoscale.ErrNotFound),
+			want: true,
+		},
+		{
+			name: "v1 param error",
+			err:  &egoscale.ErrorResponse{ErrorCode: egoscale.ParamError},
+			want: true,
+		},
+		{
+			name: "v1 other API error",
+			err:  &egoscale.ErrorResponse{ErrorCode: egoscale.Unauthorized},
+			want: false,
+		},
+		{
+			name: "v2 not found",
+			err:  exoapi.ErrNotFound,
+			want: true,
+		},
+		{
+			name: "other error",
+			err:  errors.New("boom"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isNotFoundError(tt.err); got != tt.want {
+				t.Errorf("isNotFoundError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isUnauthorizedError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "unauthorized",
+			err:  &egoscale.ErrorResponse{ErrorCode: egoscale.Unauthorized},
+			want: true,
+		},
+		{
+			name: "wrapped unauthorized",
+			err:  fmt.Errorf("request failed: %w", &egoscale.ErrorResponse{ErrorCode: egoscale.Unauthorized}),
+			want: true,
+		},
+		{
+			name: "other API error",
+			err:  &egoscale.ErrorResponse{ErrorCode: egoscale.ParamError},
+			want: false,
+		},
+		{
+			name: "other error",
+			err:  errors.New("boom"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isUnauthorizedError(tt.err); got != tt.want {
+				t.Errorf("isUnauthorizedError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isInlineProviderCredential(t *testing.T) {
+	envVars := []string{"TEST_EXOSCALE_CREDENTIAL_1", "TEST_EXOSCALE_CREDENTIAL_2"}
+
+	os.Setenv("TEST_EXOSCALE_CREDENTIAL_2", "from-env")
+	defer os.Unsetenv("TEST_EXOSCALE_CREDENTIAL_2")
+
+	tests := []struct {
+		name string
+		v    string
+		want bool
+	}{
+		{
+			name: "unset",
+			v:    "",
+			want: false,
+		},
+		{
+			name: "from environment",
+			v:    "from-env",
+			want: false,
+		},
+		{
+			name: "inline",
+			v:    "inline",
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isInlineProviderCredential(tt.v, envVars); got != tt.want {
+				t.Errorf("isInlineProviderCredential() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_providerTimeoutEnvVarsPrecedence(t *testing.T) {
+	for _, envVar := range providerTimeoutEnvVars {
+		defer os.Setenv(envVar, os.Getenv(envVar))
+		os.Unsetenv(envVar)
+	}
+
+	defaultFunc := Provider().Schema["timeout"].DefaultFunc
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want interface{}
+	}{
+		{
+			name: "unset",
+			want: defaultTimeout.Seconds(),
+		},
+		{
+			name: "legacy",
+			env:  map[string]string{"EXOSCALE_TIMEOUT": "60"},
+			want: "60",
+		},
+		{
+			name: "both",
+			env:  map[string]string{"EXOSCALE_TIMEOUT": "60", "EXOSCALE_API_TIMEOUT": "120"},
+			want: "120",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				os.Setenv(k, v)
+				defer os.Unsetenv(k)
+			}
+
+			got, err := defaultFunc()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("timeout default = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_traceEnabled(t *testing.T) {
+	for _, envVar := range providerTraceEnvVars {
+		defer os.Setenv(envVar, os.Getenv(envVar))
+		os.Unsetenv(envVar)
+	}
+
+	if traceEnabled() {
+		t.Error("traceEnabled() = true with no variable set, want false")
+	}
+
+	for _, envVar := range providerTraceEnvVars {
+		os.Setenv(envVar, "1")
+		if !traceEnabled() {
+			t.Errorf("traceEnabled() = false with %s set, want true", envVar)
+		}
+		os.Unsetenv(envVar)
+	}
+}
@@ -66,13 +66,22 @@ func resourceAffinityCreate(d *schema.ResourceData, meta interface{}) error {
 
 	client := GetComputeClient(meta)
 
+	name := d.Get("name").(string)
+
+	resp, err := client.GetWithContext(ctx, &egoscale.AffinityGroup{Name: name})
+	if err == nil {
+		return nameConflictError("exoscale_affinity", name, resp.(*egoscale.AffinityGroup).ID.String())
+	} else if !isNotFoundError(err) {
+		return err
+	}
+
 	req := &egoscale.CreateAffinityGroup{
-		Name:        d.Get("name").(string),
+		Name:        name,
 		Description: d.Get("description").(string),
 		Type:        d.Get("type").(string),
 	}
 
-	resp, err := client.RequestWithContext(ctx, req)
+	resp, err = client.RequestWithContext(ctx, req)
 	if err != nil {
 		return err
 	}
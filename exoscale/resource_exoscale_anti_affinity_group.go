@@ -0,0 +1,202 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/exoscale/egoscale"
+	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	resAntiAffinityGroupAttrDescription           = "description"
+	resAntiAffinityGroupAttrInstances             = "instances"
+	resAntiAffinityGroupAttrName                  = "name"
+	resAntiAffinityGroupAttrNameCollisionStrategy = "name_collision_strategy"
+)
+
+func resourceAntiAffinityGroupIDString(d resourceIDStringer) string {
+	return resourceIDString(d, "exoscale_anti_affinity_group")
+}
+
+func resourceAntiAffinityGroup() *schema.Resource {
+	s := map[string]*schema.Schema{
+		resAntiAffinityGroupAttrDescription: {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+		},
+		resAntiAffinityGroupAttrInstances: {
+			Type:     schema.TypeSet,
+			Computed: true,
+			Set:      schema.HashString,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		resAntiAffinityGroupAttrName: {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			DiffSuppressFunc: suppressNameCollisionSuffix,
+		},
+		resAntiAffinityGroupAttrNameCollisionStrategy: nameCollisionStrategySchema(),
+	}
+
+	return &schema.Resource{
+		Schema: s,
+
+		CreateContext: resourceAntiAffinityGroupCreate,
+		ReadContext:   resourceAntiAffinityGroupRead,
+		UpdateContext: resourceAntiAffinityGroupUpdate,
+		DeleteContext: resourceAntiAffinityGroupDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+	}
+}
+
+func resourceAntiAffinityGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning create", resourceAntiAffinityGroupIDString(d))
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), defaultZone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	err := createWithNameCollisionStrategy(
+		d,
+		"exoscale_anti_affinity_group",
+		func(name string) (string, error) {
+			existing, err := client.FindAntiAffinityGroup(ctx, defaultZone, name)
+			if err != nil {
+				if errors.Is(err, exoapi.ErrNotFound) {
+					return "", nil
+				}
+				return "", err
+			}
+			return *existing.ID, nil
+		},
+		func(name string) error {
+			antiAffinityGroup := &exov2.AntiAffinityGroup{Name: &name}
+
+			if v, ok := d.GetOk(resAntiAffinityGroupAttrDescription); ok {
+				s := v.(string)
+				antiAffinityGroup.Description = &s
+			}
+
+			antiAffinityGroup, err := client.CreateAntiAffinityGroup(ctx, defaultZone, antiAffinityGroup)
+			if err != nil {
+				return err
+			}
+
+			d.SetId(*antiAffinityGroup.ID)
+			return nil
+		},
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: create finished successfully", resourceAntiAffinityGroupIDString(d))
+
+	return resourceAntiAffinityGroupRead(ctx, d, meta)
+}
+
+func resourceAntiAffinityGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning read", resourceAntiAffinityGroupIDString(d))
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), defaultZone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	antiAffinityGroup, err := client.GetAntiAffinityGroup(ctx, defaultZone, d.Id())
+	if err != nil {
+		if errors.Is(err, exoapi.ErrNotFound) {
+			// Resource doesn't exist anymore, signaling the core to remove it from the state.
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	instances, err := antiAffinityGroupInstanceIDs(ctx, client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resAntiAffinityGroupAttrDescription, defaultString(antiAffinityGroup.Description, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resAntiAffinityGroupAttrInstances, instances); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resAntiAffinityGroupAttrName, defaultString(antiAffinityGroup.Name, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: read finished successfully", resourceAntiAffinityGroupIDString(d))
+
+	return nil
+}
+
+// resourceAntiAffinityGroupUpdate only handles changes of the name collision
+// strategy, which doesn't apply to existing resources.
+func resourceAntiAffinityGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceAntiAffinityGroupRead(ctx, d, meta)
+}
+
+func resourceAntiAffinityGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning delete", resourceAntiAffinityGroupIDString(d))
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutDelete))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), defaultZone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	if err := client.DeleteAntiAffinityGroup(ctx, defaultZone, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: delete finished successfully", resourceAntiAffinityGroupIDString(d))
+
+	return nil
+}
+
+// antiAffinityGroupInstanceIDs returns the IDs of the Compute instances
+// member of the specified Anti-Affinity Group. Members are not exposed by
+// the v2 API, falling back to the v1 API.
+func antiAffinityGroupInstanceIDs(ctx context.Context, client *egoscale.Client, id string) ([]string, error) {
+	antiAffinityGroupID, err := egoscale.ParseUUID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetWithContext(ctx, &egoscale.AffinityGroup{ID: antiAffinityGroupID})
+	if err != nil {
+		return nil, err
+	}
+
+	members := resp.(*egoscale.AffinityGroup).VirtualMachineIDs
+	ids := make([]string, len(members))
+	for i, member := range members {
+		ids[i] = member.String()
+	}
+
+	return ids, nil
+}
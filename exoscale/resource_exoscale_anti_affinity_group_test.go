@@ -0,0 +1,141 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAccResourceAntiAffinityGroupName        = acctest.RandomWithPrefix(testPrefix)
+	testAccResourceAntiAffinityGroupDescription = acctest.RandString(10)
+
+	testAccResourceAntiAffinityGroupConfig = fmt.Sprintf(`
+resource "exoscale_anti_affinity_group" "test" {
+  name = "%s"
+  description = "%s"
+}
+
+resource "exoscale_compute_instance" "test" {
+  zone = "%s"
+  name = "%s"
+  type = "standard.tiny"
+  template_id = "%s"
+  anti_affinity_group_ids = [exoscale_anti_affinity_group.test.id]
+}
+`,
+		testAccResourceAntiAffinityGroupName,
+		testAccResourceAntiAffinityGroupDescription,
+		testZoneName,
+		testAccResourceAntiAffinityGroupName,
+		testInstanceTemplateID,
+	)
+)
+
+func TestAccResourceAntiAffinityGroup(t *testing.T) {
+	var (
+		r                 = "exoscale_anti_affinity_group.test"
+		antiAffinityGroup exov2.AntiAffinityGroup
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckResourceAntiAffinityGroupDestroy(&antiAffinityGroup),
+		Steps: []resource.TestStep{
+			{
+				// Create
+				Config: testAccResourceAntiAffinityGroupConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceAntiAffinityGroupExists(r, &antiAffinityGroup),
+					func(s *terraform.State) error {
+						a := require.New(t)
+
+						a.Equal(testAccResourceAntiAffinityGroupName, *antiAffinityGroup.Name)
+						a.Equal(testAccResourceAntiAffinityGroupDescription, *antiAffinityGroup.Description)
+
+						return nil
+					},
+					// The Anti-Affinity Group is read before the Compute instance is created,
+					// its members are checked on import instead.
+					checkResourceState(r, checkResourceStateValidateAttributes(testAttrs{
+						resAntiAffinityGroupAttrDescription: validateString(testAccResourceAntiAffinityGroupDescription),
+						resAntiAffinityGroupAttrName:        validateString(testAccResourceAntiAffinityGroupName),
+					})),
+				),
+			},
+			{
+				// Import
+				ResourceName:            r,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{resAntiAffinityGroupAttrInstances, resAntiAffinityGroupAttrNameCollisionStrategy},
+				ImportStateCheck: func(s []*terraform.InstanceState) error {
+					return checkResourceAttributes(
+						testAttrs{
+							resAntiAffinityGroupAttrDescription:      validateString(testAccResourceAntiAffinityGroupDescription),
+							resAntiAffinityGroupAttrInstances + ".#": validateString("1"),
+							resAntiAffinityGroupAttrName:             validateString(testAccResourceAntiAffinityGroupName),
+						},
+						s[0].Attributes)
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckResourceAntiAffinityGroupExists(r string, antiAffinityGroup *exov2.AntiAffinityGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[r]
+		if !ok {
+			return errors.New("resource not found in the state")
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("resource ID not set")
+		}
+
+		client := GetComputeClient(testAccProvider.Meta())
+		ctx := exoapi.WithEndpoint(
+			context.Background(),
+			exoapi.NewReqEndpoint(testEnvironment, defaultZone),
+		)
+
+		res, err := client.Client.GetAntiAffinityGroup(ctx, defaultZone, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*antiAffinityGroup = *res
+		return nil
+	}
+}
+
+func testAccCheckResourceAntiAffinityGroupDestroy(antiAffinityGroup *exov2.AntiAffinityGroup) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		client := GetComputeClient(testAccProvider.Meta())
+		ctx := exoapi.WithEndpoint(
+			context.Background(),
+			exoapi.NewReqEndpoint(testEnvironment, defaultZone),
+		)
+
+		_, err := client.GetAntiAffinityGroup(ctx, defaultZone, *antiAffinityGroup.ID)
+		if err != nil {
+			if errors.Is(err, exoapi.ErrNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		return errors.New("Anti-Affinity Group still exists")
+	}
+}
@@ -206,6 +206,8 @@ func resourceCompute() *schema.Resource {
 		Delete: resourceComputeDelete,
 		Exists: resourceComputeExists,
 
+		CustomizeDiff: resourceComputeCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceComputeImport,
 		},
@@ -219,6 +221,20 @@ func resourceCompute() *schema.Resource {
 	}
 }
 
+// resourceComputeCustomizeDiff rejects decreasing the disk size of the Compute
+// instance at plan time, as volumes can only be expanded.
+func resourceComputeCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("disk_size") {
+		return nil
+	}
+
+	if o, n := d.GetChange("disk_size"); n.(int) < o.(int) {
+		return fmt.Errorf("A volume can only be expanded. From %dG to %dG is not allowed", o.(int), n.(int))
+	}
+
+	return nil
+}
+
 func resourceComputeCreate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] %s: beginning create", resourceComputeIDString(d))
 
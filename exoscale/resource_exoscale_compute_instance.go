@@ -0,0 +1,829 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/exoscale/egoscale"
+	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	defaultComputeInstanceDiskSize = 10
+
+	computeInstanceStateRunning = "running"
+	computeInstanceStateStopped = "stopped"
+
+	resComputeInstanceAttrAllowRebootForResize      = "allow_reboot_for_resize"
+	resComputeInstanceAttrAntiAffinityGroupIDs      = "anti_affinity_group_ids"
+	resComputeInstanceAttrCreatedAt                 = "created_at"
+	resComputeInstanceAttrDeployTargetID            = "deploy_target_id"
+	resComputeInstanceAttrDiskSize                  = "disk_size"
+	resComputeInstanceAttrElasticIPIDs              = "elastic_ip_ids"
+	resComputeInstanceAttrIPv6                      = "ipv6"
+	resComputeInstanceAttrIPv6Address               = "ipv6_address"
+	resComputeInstanceAttrLabels                    = "labels"
+	resComputeInstanceAttrManagerID                 = "manager_id"
+	resComputeInstanceAttrManagerType               = "manager_type"
+	resComputeInstanceAttrName                      = "name"
+	resComputeInstanceAttrNetworkInterface          = "network_interface"
+	resComputeInstanceAttrNetworkInterfaceIPAddress = "ip_address"
+	resComputeInstanceAttrNetworkInterfaceNetworkID = "network_id"
+	resComputeInstanceAttrPublicIPAddress           = "public_ip_address"
+	resComputeInstanceAttrSSHKey                    = "ssh_key"
+	resComputeInstanceAttrSecurityGroupIDs          = "security_group_ids"
+	resComputeInstanceAttrState                     = "state"
+	resComputeInstanceAttrTemplateID                = "template_id"
+	resComputeInstanceAttrType                      = "type"
+	resComputeInstanceAttrUserData                  = "user_data"
+	resComputeInstanceAttrUserDataReplaceOnChange   = "user_data_replace_on_change"
+	resComputeInstanceAttrZone                      = "zone"
+)
+
+// computeInstanceStoppingChanges lists the attributes whose change requires
+// stopping a running Compute instance, which has to be allowed by setting the
+// "allow_reboot_for_resize" attribute.
+var computeInstanceStoppingChanges = []struct{ attr, what string }{
+	{resComputeInstanceAttrAntiAffinityGroupIDs, "the Anti-Affinity Groups"},
+	{resComputeInstanceAttrDiskSize, "the disk size"},
+	{resComputeInstanceAttrType, "the type"},
+}
+
+func resourceComputeInstanceIDString(d resourceIDStringer) string {
+	return resourceIDString(d, "exoscale_compute_instance")
+}
+
+func resourceComputeInstance() *schema.Resource {
+	s := map[string]*schema.Schema{
+		resComputeInstanceAttrAllowRebootForResize: {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		resComputeInstanceAttrAntiAffinityGroupIDs: {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Set:      schema.HashString,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		resComputeInstanceAttrCreatedAt: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		resComputeInstanceAttrDeployTargetID: {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+		},
+		resComputeInstanceAttrDiskSize: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      defaultComputeInstanceDiskSize,
+			ValidateFunc: validation.IntAtLeast(10),
+		},
+		resComputeInstanceAttrElasticIPIDs: {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Computed: true,
+			Set:      schema.HashString,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		resComputeInstanceAttrIPv6: {
+			Type:     schema.TypeBool,
+			Optional: true,
+			ForceNew: true,
+		},
+		resComputeInstanceAttrIPv6Address: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		resComputeInstanceAttrLabels: {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		resComputeInstanceAttrManagerID: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		resComputeInstanceAttrManagerType: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		resComputeInstanceAttrName: {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		resComputeInstanceAttrNetworkInterface: {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					resComputeInstanceAttrNetworkInterfaceIPAddress: {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.IsIPv4Address,
+					},
+					resComputeInstanceAttrNetworkInterfaceNetworkID: {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+				},
+			},
+		},
+		resComputeInstanceAttrPublicIPAddress: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		resComputeInstanceAttrSSHKey: {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+		},
+		resComputeInstanceAttrSecurityGroupIDs: {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Computed: true,
+			Set:      schema.HashString,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		resComputeInstanceAttrState: {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				computeInstanceStateRunning,
+				computeInstanceStateStopped,
+			}, false),
+		},
+		resComputeInstanceAttrTemplateID: {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		resComputeInstanceAttrType: {
+			Type:     schema.TypeString,
+			Required: true,
+			ValidateFunc: func(i interface{}, _ string) ([]string, []error) {
+				if parts := strings.SplitN(i.(string), ".", 2); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+					return nil, []error{fmt.Errorf(`invalid value %q, expected format "FAMILY.SIZE"`, i.(string))}
+				}
+				return nil, nil
+			},
+		},
+		resComputeInstanceAttrUserData: {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		resComputeInstanceAttrUserDataReplaceOnChange: {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		resComputeInstanceAttrZone: {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+	}
+
+	return &schema.Resource{
+		Schema: s,
+
+		CreateContext: resourceComputeInstanceCreate,
+		ReadContext:   resourceComputeInstanceRead,
+		UpdateContext: resourceComputeInstanceUpdate,
+		DeleteContext: resourceComputeInstanceDelete,
+
+		CustomizeDiff: resourceComputeInstanceCustomizeDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: zonedStateContextFunc,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+	}
+}
+
+// resourceComputeInstanceCustomizeDiff rejects at plan time attaching the same
+// Private Network more than once, decreasing the disk size of the Compute
+// instance, as disks can only be grown, as well as changing the type of a
+// running Compute instance without allowing it to be rebooted. It also forces
+// the replacement of the Compute instance on user data change if requested,
+// as user data changes are otherwise only applied on next reboot.
+func resourceComputeInstanceCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if set, ok := d.Get(resComputeInstanceAttrNetworkInterface).(*schema.Set); ok {
+		seen := make(map[string]struct{})
+		for _, v := range set.List() {
+			id := v.(map[string]interface{})[resComputeInstanceAttrNetworkInterfaceNetworkID].(string)
+			if _, ok := seen[id]; ok && id != "" {
+				return fmt.Errorf("Private Network %s can only be attached once", id)
+			}
+			seen[id] = struct{}{}
+		}
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	if d.HasChange(resComputeInstanceAttrUserData) && d.Get(resComputeInstanceAttrUserDataReplaceOnChange).(bool) {
+		if err := d.ForceNew(resComputeInstanceAttrUserData); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange(resComputeInstanceAttrDiskSize) {
+		if o, n := d.GetChange(resComputeInstanceAttrDiskSize); n.(int) < o.(int) {
+			return fmt.Errorf("disk size can only be increased (from %dGB to %dGB is not allowed)", o.(int), n.(int))
+		}
+	}
+
+	// The API doesn't detach a Compute instance from its Anti-Affinity Groups
+	// when updating them with an empty list, the instance has to be
+	// re-created instead.
+	var antiAffinityGroupsCleared bool
+	if o, n := d.GetChange(resComputeInstanceAttrAntiAffinityGroupIDs); o.(*schema.Set).Len() > 0 &&
+		n.(*schema.Set).Len() == 0 {
+		if err := d.ForceNew(resComputeInstanceAttrAntiAffinityGroupIDs); err != nil {
+			return err
+		}
+		antiAffinityGroupsCleared = true
+	}
+
+	if !d.Get(resComputeInstanceAttrAllowRebootForResize).(bool) && !antiAffinityGroupsCleared {
+		if o, _ := d.GetChange(resComputeInstanceAttrState); o.(string) == computeInstanceStateRunning {
+			for _, change := range computeInstanceStoppingChanges {
+				if d.HasChange(change.attr) {
+					return fmt.Errorf(
+						"changing %s of a running Compute instance requires stopping it: set %q to true to allow it",
+						change.what,
+						resComputeInstanceAttrAllowRebootForResize,
+					)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceComputeInstanceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning create", resourceComputeInstanceIDString(d))
+
+	zone := d.Get(resComputeInstanceAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	instance := new(exov2.Instance)
+
+	if set, ok := d.Get(resComputeInstanceAttrAntiAffinityGroupIDs).(*schema.Set); ok && set.Len() > 0 {
+		ids := make([]string, set.Len())
+		for i, v := range set.List() {
+			ids[i] = v.(string)
+		}
+		instance.AntiAffinityGroupIDs = &ids
+	}
+
+	if v, ok := d.GetOk(resComputeInstanceAttrDeployTargetID); ok {
+		s := v.(string)
+		instance.DeployTargetID = &s
+	}
+
+	diskSize := int64(d.Get(resComputeInstanceAttrDiskSize).(int))
+	instance.DiskSize = &diskSize
+
+	enableIPv6 := d.Get(resComputeInstanceAttrIPv6).(bool)
+	instance.IPv6Enabled = &enableIPv6
+
+	if l, ok := d.GetOk(resComputeInstanceAttrLabels); ok {
+		labels := make(map[string]string)
+		for k, v := range l.(map[string]interface{}) {
+			labels[k] = v.(string)
+		}
+		instance.Labels = &labels
+	}
+
+	name := d.Get(resComputeInstanceAttrName).(string)
+	instance.Name = &name
+
+	if v, ok := d.GetOk(resComputeInstanceAttrSSHKey); ok {
+		s := v.(string)
+		instance.SSHKey = &s
+	}
+
+	if set, ok := d.Get(resComputeInstanceAttrSecurityGroupIDs).(*schema.Set); ok && set.Len() > 0 {
+		ids := make([]string, set.Len())
+		for i, v := range set.List() {
+			ids[i] = v.(string)
+		}
+		instance.SecurityGroupIDs = &ids
+	}
+
+	templateID := d.Get(resComputeInstanceAttrTemplateID).(string)
+	instance.TemplateID = &templateID
+
+	instanceType, err := findInstanceType(ctx, client, zone, d.Get(resComputeInstanceAttrType).(string))
+	if err != nil {
+		return diag.Errorf("error retrieving instance type: %s", err)
+	}
+	instance.InstanceTypeID = instanceType.ID
+
+	if v := d.Get(resComputeInstanceAttrUserData).(string); v != "" {
+		userData, err := encodeUserData(v)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		instance.UserData = &userData
+	}
+
+	instance, err = client.CreateInstance(ctx, zone, instance)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(*instance.ID)
+
+	if set, ok := d.Get(resComputeInstanceAttrElasticIPIDs).(*schema.Set); ok {
+		for _, v := range set.List() {
+			id := v.(string)
+			if err := instance.AttachElasticIP(ctx, &exov2.ElasticIP{ID: &id}); err != nil {
+				return diag.Errorf("unable to attach Elastic IP %s: %s", id, err)
+			}
+		}
+	}
+
+	if set, ok := d.Get(resComputeInstanceAttrNetworkInterface).(*schema.Set); ok {
+		for _, v := range set.List() {
+			if err := attachComputeInstancePrivateNetwork(ctx, instance, v.(map[string]interface{})); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	if d.Get(resComputeInstanceAttrState).(string) == computeInstanceStateStopped {
+		if err := instance.Stop(ctx); err != nil {
+			return diag.Errorf("unable to stop Compute instance: %s", err)
+		}
+	}
+
+	log.Printf("[DEBUG] %s: create finished successfully", resourceComputeInstanceIDString(d))
+
+	return resourceComputeInstanceRead(ctx, d, meta)
+}
+
+func resourceComputeInstanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning read", resourceComputeInstanceIDString(d))
+
+	zone := d.Get(resComputeInstanceAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	instance, err := client.GetInstance(ctx, zone, d.Id())
+	if err != nil {
+		if errors.Is(err, exoapi.ErrNotFound) {
+			// Resource doesn't exist anymore, signaling the core to remove it from the state.
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: read finished successfully", resourceComputeInstanceIDString(d))
+
+	return resourceComputeInstanceApply(ctx, client, d, instance)
+}
+
+func resourceComputeInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning update", resourceComputeInstanceIDString(d))
+
+	zone := d.Get(resComputeInstanceAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutUpdate))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	instance, err := client.GetInstance(ctx, zone, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var updated bool
+
+	state := defaultString(instance.State, "")
+
+	if d.HasChange(resComputeInstanceAttrLabels) {
+		labels := make(map[string]string)
+		for k, v := range d.Get(resComputeInstanceAttrLabels).(map[string]interface{}) {
+			labels[k] = v.(string)
+		}
+		instance.Labels = &labels
+		updated = true
+	}
+
+	if d.HasChange(resComputeInstanceAttrName) {
+		v := d.Get(resComputeInstanceAttrName).(string)
+		instance.Name = &v
+		updated = true
+	}
+
+	if d.HasChange(resComputeInstanceAttrUserData) {
+		v, err := encodeUserData(d.Get(resComputeInstanceAttrUserData).(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		instance.UserData = &v
+		updated = true
+	}
+
+	if updated {
+		if err = client.UpdateInstance(ctx, zone, instance); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// Compute instances must be stopped to have their disk resized, to be
+	// scaled or to have their Anti-Affinity Groups changed, they are started
+	// again afterwards according to the "state" attribute.
+	if state == computeInstanceStateRunning {
+		for _, change := range computeInstanceStoppingChanges {
+			if d.HasChange(change.attr) {
+				if err = instance.Stop(ctx); err != nil {
+					return diag.Errorf("unable to stop Compute instance: %s", err)
+				}
+				state = computeInstanceStateStopped
+				break
+			}
+		}
+	}
+
+	if d.HasChange(resComputeInstanceAttrDiskSize) {
+		if err = instance.ResizeDisk(ctx, int64(d.Get(resComputeInstanceAttrDiskSize).(int))); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange(resComputeInstanceAttrType) {
+		instanceType, err := findInstanceType(ctx, client, zone, d.Get(resComputeInstanceAttrType).(string))
+		if err != nil {
+			return diag.Errorf("error retrieving instance type: %s", err)
+		}
+
+		if err = instance.Scale(ctx, instanceType); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange(resComputeInstanceAttrAntiAffinityGroupIDs) {
+		// The v2 API doesn't support updating the Anti-Affinity Groups of
+		// an existing Compute instance, falling back to the v1 API.
+		instanceID, err := egoscale.ParseUUID(d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		req := &egoscale.UpdateVMAffinityGroup{ID: instanceID}
+		for _, v := range d.Get(resComputeInstanceAttrAntiAffinityGroupIDs).(*schema.Set).List() {
+			id, err := egoscale.ParseUUID(v.(string))
+			if err != nil {
+				return diag.Errorf("invalid Anti-Affinity Group ID %q: %s", v.(string), err)
+			}
+			req.AffinityGroupIDs = append(req.AffinityGroupIDs, *id)
+		}
+
+		if _, err = client.RequestWithContext(ctx, req); err != nil {
+			return diag.Errorf("unable to update Compute instance Anti-Affinity Groups: %s", err)
+		}
+	}
+
+	if d.HasChange(resComputeInstanceAttrElasticIPIDs) {
+		o, n := d.GetChange(resComputeInstanceAttrElasticIPIDs)
+		old := o.(*schema.Set)
+		new := n.(*schema.Set)
+
+		for _, v := range old.Difference(new).List() {
+			id := v.(string)
+			if err := instance.DetachElasticIP(ctx, &exov2.ElasticIP{ID: &id}); err != nil {
+				return diag.Errorf("unable to detach Elastic IP %s: %s", id, err)
+			}
+		}
+
+		for _, v := range new.Difference(old).List() {
+			id := v.(string)
+			if err := instance.AttachElasticIP(ctx, &exov2.ElasticIP{ID: &id}); err != nil {
+				return diag.Errorf("unable to attach Elastic IP %s: %s", id, err)
+			}
+		}
+	}
+
+	if d.HasChange(resComputeInstanceAttrNetworkInterface) {
+		o, n := d.GetChange(resComputeInstanceAttrNetworkInterface)
+		old := computeInstanceNetworkInterfaces(o.(*schema.Set))
+		new := computeInstanceNetworkInterfaces(n.(*schema.Set))
+
+		for oldID := range old {
+			if _, ok := new[oldID]; ok {
+				continue
+			}
+
+			id := oldID
+			if err := instance.DetachPrivateNetwork(ctx, &exov2.PrivateNetwork{ID: &id}); err != nil {
+				return diag.Errorf("unable to detach Private Network %s: %s", id, err)
+			}
+		}
+
+		for _, v := range n.(*schema.Set).List() {
+			networkInterface := v.(map[string]interface{})
+			id := networkInterface[resComputeInstanceAttrNetworkInterfaceNetworkID].(string)
+			ipAddress := networkInterface[resComputeInstanceAttrNetworkInterfaceIPAddress].(string)
+
+			oldIPAddress, attached := old[id]
+			switch {
+			case !attached:
+				if err := attachComputeInstancePrivateNetwork(ctx, instance, networkInterface); err != nil {
+					return diag.FromErr(err)
+				}
+
+			// The static DHCP lease of an attached managed Private Network can
+			// be changed in place, without detaching the Compute instance.
+			case ipAddress != "" && ipAddress != oldIPAddress:
+				privateNetwork, err := client.GetPrivateNetwork(ctx, zone, id)
+				if err != nil {
+					return diag.Errorf("unable to retrieve Private Network %s: %s", id, err)
+				}
+
+				if err := privateNetwork.UpdateInstanceIPAddress(ctx, instance, net.ParseIP(ipAddress)); err != nil {
+					return diag.Errorf("unable to update Private Network %s IP address: %s", id, err)
+				}
+			}
+		}
+	}
+
+	if d.HasChange(resComputeInstanceAttrSecurityGroupIDs) {
+		o, n := d.GetChange(resComputeInstanceAttrSecurityGroupIDs)
+		old := o.(*schema.Set)
+		new := n.(*schema.Set)
+
+		for _, v := range old.Difference(new).List() {
+			id := v.(string)
+			if err := instance.DetachSecurityGroup(ctx, &exov2.SecurityGroup{ID: &id}); err != nil {
+				return diag.Errorf("unable to detach Security Group %s: %s", id, err)
+			}
+		}
+
+		for _, v := range new.Difference(old).List() {
+			id := v.(string)
+			if err := instance.AttachSecurityGroup(ctx, &exov2.SecurityGroup{ID: &id}); err != nil {
+				return diag.Errorf("unable to attach Security Group %s: %s", id, err)
+			}
+		}
+	}
+
+	switch d.Get(resComputeInstanceAttrState).(string) {
+	case computeInstanceStateRunning:
+		if state != computeInstanceStateRunning {
+			if err = instance.Start(ctx); err != nil {
+				return diag.Errorf("unable to start Compute instance: %s", err)
+			}
+		}
+
+	case computeInstanceStateStopped:
+		if state != computeInstanceStateStopped {
+			if err = instance.Stop(ctx); err != nil {
+				return diag.Errorf("unable to stop Compute instance: %s", err)
+			}
+		}
+	}
+
+	log.Printf("[DEBUG] %s: update finished successfully", resourceComputeInstanceIDString(d))
+
+	return resourceComputeInstanceRead(ctx, d, meta)
+}
+
+func resourceComputeInstanceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning delete", resourceComputeInstanceIDString(d))
+
+	zone := d.Get(resComputeInstanceAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutDelete))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	if err := client.DeleteInstance(ctx, zone, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: delete finished successfully", resourceComputeInstanceIDString(d))
+
+	return nil
+}
+
+func resourceComputeInstanceApply(
+	ctx context.Context,
+	client *egoscale.Client,
+	d *schema.ResourceData,
+	instance *exov2.Instance,
+) diag.Diagnostics {
+	zone := d.Get(resComputeInstanceAttrZone).(string)
+
+	if instance.AntiAffinityGroupIDs != nil {
+		if err := d.Set(resComputeInstanceAttrAntiAffinityGroupIDs, *instance.AntiAffinityGroupIDs); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if instance.CreatedAt != nil {
+		if err := d.Set(resComputeInstanceAttrCreatedAt, instance.CreatedAt.String()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := d.Set(resComputeInstanceAttrDeployTargetID, defaultString(instance.DeployTargetID, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resComputeInstanceAttrDiskSize, defaultInt64(instance.DiskSize, 0)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	elasticIPIDs := make([]string, 0)
+	if instance.ElasticIPIDs != nil {
+		elasticIPIDs = *instance.ElasticIPIDs
+	}
+	if err := d.Set(resComputeInstanceAttrElasticIPIDs, elasticIPIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resComputeInstanceAttrIPv6, defaultBool(instance.IPv6Enabled, false)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var ipv6Address, publicIPAddress string
+	if instance.IPv6Address != nil {
+		ipv6Address = instance.IPv6Address.String()
+	}
+	if instance.PublicIPAddress != nil {
+		publicIPAddress = instance.PublicIPAddress.String()
+	}
+	if err := d.Set(resComputeInstanceAttrIPv6Address, ipv6Address); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(resComputeInstanceAttrPublicIPAddress, publicIPAddress); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if instance.Labels != nil {
+		if err := d.Set(resComputeInstanceAttrLabels, *instance.Labels); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if err := d.Set(resComputeInstanceAttrLabels, map[string]string{}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var managerID, managerType string
+	if instance.Manager != nil {
+		managerID = instance.Manager.ID
+		managerType = instance.Manager.Type
+	}
+	if err := d.Set(resComputeInstanceAttrManagerID, managerID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(resComputeInstanceAttrManagerType, managerType); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resComputeInstanceAttrName, defaultString(instance.Name, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The IP address of a Private Network interface is only reported if it
+	// has been specified in the configuration, and is retrieved from the
+	// Private Network DHCP leases (i.e. for managed Private Networks only).
+	configuredIPAddresses := computeInstanceNetworkInterfaces(d.Get(resComputeInstanceAttrNetworkInterface).(*schema.Set))
+	networkInterfaces := make([]interface{}, 0)
+	if instance.PrivateNetworkIDs != nil {
+		for _, id := range *instance.PrivateNetworkIDs {
+			ipAddress := configuredIPAddresses[id]
+			if ipAddress != "" {
+				privateNetwork, err := client.GetPrivateNetwork(ctx, zone, id)
+				if err != nil {
+					return diag.Errorf("unable to retrieve Private Network %s: %s", id, err)
+				}
+
+				for _, lease := range privateNetwork.Leases {
+					if defaultString(lease.InstanceID, "") == *instance.ID {
+						ipAddress = lease.IPAddress.String()
+					}
+				}
+			}
+
+			networkInterfaces = append(networkInterfaces, map[string]interface{}{
+				resComputeInstanceAttrNetworkInterfaceIPAddress: ipAddress,
+				resComputeInstanceAttrNetworkInterfaceNetworkID: id,
+			})
+		}
+	}
+	if err := d.Set(resComputeInstanceAttrNetworkInterface, networkInterfaces); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resComputeInstanceAttrSSHKey, defaultString(instance.SSHKey, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	securityGroupIDs := make([]string, 0)
+	if instance.SecurityGroupIDs != nil {
+		securityGroupIDs = *instance.SecurityGroupIDs
+	}
+	if err := d.Set(resComputeInstanceAttrSecurityGroupIDs, securityGroupIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resComputeInstanceAttrState, defaultString(instance.State, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resComputeInstanceAttrTemplateID, defaultString(instance.TemplateID, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	instanceType, err := client.GetInstanceType(ctx, zone, *instance.InstanceTypeID)
+	if err != nil {
+		return diag.Errorf("error retrieving instance type: %s", err)
+	}
+	if err := d.Set(resComputeInstanceAttrType, fmt.Sprintf(
+		"%s.%s",
+		strings.ToLower(*instanceType.Family),
+		strings.ToLower(*instanceType.Size),
+	)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if instance.UserData != nil {
+		userData, err := decodeUserData(*instance.UserData)
+		if err != nil {
+			return diag.Errorf("unable to decode user data: %s", err)
+		}
+		if err := d.Set(resComputeInstanceAttrUserData, userData); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// computeInstanceNetworkInterfaces returns the IP addresses of a set of
+// "network_interface" blocks indexed by Private Network ID.
+func computeInstanceNetworkInterfaces(set *schema.Set) map[string]string {
+	networkInterfaces := make(map[string]string, set.Len())
+	for _, v := range set.List() {
+		networkInterface := v.(map[string]interface{})
+		networkInterfaces[networkInterface[resComputeInstanceAttrNetworkInterfaceNetworkID].(string)] =
+			networkInterface[resComputeInstanceAttrNetworkInterfaceIPAddress].(string)
+	}
+
+	return networkInterfaces
+}
+
+// attachComputeInstancePrivateNetwork attaches the Compute instance to the
+// Private Network of a "network_interface" block.
+func attachComputeInstancePrivateNetwork(
+	ctx context.Context,
+	instance *exov2.Instance,
+	networkInterface map[string]interface{},
+) error {
+	id := networkInterface[resComputeInstanceAttrNetworkInterfaceNetworkID].(string)
+
+	var ipAddress net.IP
+	if v := networkInterface[resComputeInstanceAttrNetworkInterfaceIPAddress].(string); v != "" {
+		ipAddress = net.ParseIP(v)
+	}
+
+	if err := instance.AttachPrivateNetwork(ctx, &exov2.PrivateNetwork{ID: &id}, ipAddress); err != nil {
+		return fmt.Errorf("unable to attach Private Network %s: %w", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,389 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAccResourceComputeInstanceName        = acctest.RandomWithPrefix(testPrefix)
+	testAccResourceComputeInstanceNameUpdated = testAccResourceComputeInstanceName + "-updated"
+	testAccResourceComputeInstanceLabelValue  = acctest.RandString(10)
+	testAccResourceComputeInstanceTemplateID  = testInstanceTemplateID
+	testAccResourceComputeInstanceUserData    = `#cloud-config
+package_upgrade: true
+`
+
+	testAccResourceComputeInstanceConfigCreate = fmt.Sprintf(`
+locals {
+  zone = "%s"
+}
+
+resource "exoscale_security_group" "test" {
+  name = "%s"
+}
+
+resource "exoscale_network" "test" {
+  zone = local.zone
+  name = "%s"
+  start_ip = "10.0.0.10"
+  end_ip = "10.0.0.20"
+  netmask = "255.255.255.0"
+}
+
+resource "exoscale_compute_instance" "test" {
+  zone = local.zone
+  name = "%s"
+  type = "standard.tiny"
+  template_id = "%s"
+  disk_size = 10
+  ipv6 = true
+  security_group_ids = [exoscale_security_group.test.id]
+  user_data = <<EOF
+%s
+EOF
+  labels = {
+    test = "%s"
+  }
+
+  network_interface {
+    network_id = exoscale_network.test.id
+    ip_address = "10.0.0.11"
+  }
+
+  timeouts {
+    delete = "10m"
+  }
+}
+`,
+		testZoneName,
+		testAccResourceComputeInstanceName,
+		testAccResourceComputeInstanceName,
+		testAccResourceComputeInstanceName,
+		testAccResourceComputeInstanceTemplateID,
+		testAccResourceComputeInstanceUserData,
+		testAccResourceComputeInstanceLabelValue,
+	)
+
+	testAccResourceComputeInstanceConfigUpdate = fmt.Sprintf(`
+locals {
+  zone = "%s"
+}
+
+resource "exoscale_security_group" "test" {
+  name = "%s"
+}
+
+resource "exoscale_network" "test" {
+  zone = local.zone
+  name = "%s"
+  start_ip = "10.0.0.10"
+  end_ip = "10.0.0.20"
+  netmask = "255.255.255.0"
+}
+
+resource "exoscale_ipaddress" "test" {
+  zone = local.zone
+}
+
+resource "exoscale_affinity" "test" {
+  name = "%s"
+}
+
+resource "exoscale_compute_instance" "test" {
+  zone = local.zone
+  name = "%s"
+  type = "standard.small"
+  template_id = "%s"
+  disk_size = 15
+  allow_reboot_for_resize = true
+  ipv6 = true
+  security_group_ids = [exoscale_security_group.test.id]
+  elastic_ip_ids = [exoscale_ipaddress.test.id]
+  anti_affinity_group_ids = [exoscale_affinity.test.id]
+  labels = {}
+  state = "stopped"
+
+  network_interface {
+    network_id = exoscale_network.test.id
+    ip_address = "10.0.0.12"
+  }
+
+  timeouts {
+    delete = "10m"
+  }
+}
+`,
+		testZoneName,
+		testAccResourceComputeInstanceName,
+		testAccResourceComputeInstanceName,
+		testAccResourceComputeInstanceName,
+		testAccResourceComputeInstanceNameUpdated,
+		testAccResourceComputeInstanceTemplateID,
+	)
+
+	testAccResourceComputeInstanceConfigClearAntiAffinityGroups = strings.Replace(
+		testAccResourceComputeInstanceConfigUpdate,
+		"  anti_affinity_group_ids = [exoscale_affinity.test.id]\n",
+		"",
+		1,
+	)
+)
+
+func TestAccResourceComputeInstance(t *testing.T) {
+	var (
+		r          = "exoscale_compute_instance.test"
+		instance   exov2.Instance
+		instanceID string
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckResourceComputeInstanceDestroy(&instance),
+		Steps: []resource.TestStep{
+			{
+				// Create
+				Config: testAccResourceComputeInstanceConfigCreate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceComputeInstanceExists(r, &instance),
+					func(s *terraform.State) error {
+						a := require.New(t)
+
+						a.Equal(int64(10), *instance.DiskSize)
+						a.True(*instance.IPv6Enabled)
+						a.Equal(map[string]string{"test": testAccResourceComputeInstanceLabelValue}, *instance.Labels)
+						a.Equal(testAccResourceComputeInstanceName, *instance.Name)
+						a.Len(*instance.PrivateNetworkIDs, 1)
+						a.Len(*instance.SecurityGroupIDs, 1)
+						a.Equal(testAccResourceComputeInstanceTemplateID, *instance.TemplateID)
+
+						return nil
+					},
+					checkResourceState(r, checkResourceStateValidateAttributes(testAttrs{
+						resComputeInstanceAttrCreatedAt:               validation.ToDiagFunc(validation.NoZeroValues),
+						resComputeInstanceAttrDiskSize:                validateString("10"),
+						resComputeInstanceAttrIPv6:                    validateString("true"),
+						resComputeInstanceAttrIPv6Address:             validation.ToDiagFunc(validation.IsIPv6Address),
+						resComputeInstanceAttrLabels + ".test":        validateString(testAccResourceComputeInstanceLabelValue),
+						resComputeInstanceAttrName:                    validateString(testAccResourceComputeInstanceName),
+						resComputeInstanceAttrNetworkInterface + ".#": validateString("1"),
+						resComputeInstanceAttrPublicIPAddress:         validation.ToDiagFunc(validation.IsIPv4Address),
+						resComputeInstanceAttrSecurityGroupIDs + ".#": validateString("1"),
+						resComputeInstanceAttrState:                   validateString(computeInstanceStateRunning),
+						resComputeInstanceAttrTemplateID:              validateString(testAccResourceComputeInstanceTemplateID),
+						resComputeInstanceAttrType:                    validateString("standard.tiny"),
+						resComputeInstanceAttrUserData:                validateString(testAccResourceComputeInstanceUserData + "\n"),
+						resComputeInstanceAttrZone:                    validateString(testZoneName),
+					})),
+				),
+			},
+			{
+				// Update
+				Config: testAccResourceComputeInstanceConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceComputeInstanceExists(r, &instance),
+					func(s *terraform.State) error {
+						a := require.New(t)
+
+						a.Len(*instance.AntiAffinityGroupIDs, 1)
+						a.Equal(int64(15), *instance.DiskSize)
+						a.Len(*instance.ElasticIPIDs, 1)
+						a.Empty(defaultString(instance.UserData, ""))
+						a.Equal(testAccResourceComputeInstanceNameUpdated, *instance.Name)
+						a.Len(*instance.PrivateNetworkIDs, 1)
+						a.Equal(computeInstanceStateStopped, *instance.State)
+
+						return nil
+					},
+					checkResourceState(r, checkResourceStateValidateAttributes(testAttrs{
+						resComputeInstanceAttrAntiAffinityGroupIDs + ".#": validateString("1"),
+						resComputeInstanceAttrDiskSize:                    validateString("15"),
+						resComputeInstanceAttrElasticIPIDs + ".#":         validateString("1"),
+						resComputeInstanceAttrLabels + ".%":               validateString("0"),
+						resComputeInstanceAttrName:                        validateString(testAccResourceComputeInstanceNameUpdated),
+						resComputeInstanceAttrNetworkInterface + ".#":     validateString("1"),
+						resComputeInstanceAttrState:                       validateString(computeInstanceStateStopped),
+						resComputeInstanceAttrType:                        validateString("standard.small"),
+						resComputeInstanceAttrUserData:                    validation.ToDiagFunc(validation.StringIsEmpty),
+					})),
+				),
+			},
+			{
+				// Import
+				ResourceName: r,
+				ImportStateIdFunc: func(instance *exov2.Instance) resource.ImportStateIdFunc {
+					return func(*terraform.State) (string, error) {
+						return fmt.Sprintf("%s@%s", *instance.ID, testZoneName), nil
+					}
+				}(&instance),
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					resComputeInstanceAttrAllowRebootForResize,
+					resComputeInstanceAttrUserDataReplaceOnChange,
+				},
+				ImportStateCheck: func(s []*terraform.InstanceState) error {
+					return checkResourceAttributes(
+						testAttrs{
+							resComputeInstanceAttrDiskSize:            validateString("15"),
+							resComputeInstanceAttrElasticIPIDs + ".#": validateString("1"),
+							resComputeInstanceAttrName:                validateString(testAccResourceComputeInstanceNameUpdated),
+							resComputeInstanceAttrType:                validateString("standard.small"),
+							resComputeInstanceAttrZone:                validateString(testZoneName),
+						},
+						s[0].Attributes)
+				},
+			},
+			{
+				// Clear the Anti-Affinity Groups (re-creates the instance)
+				PreConfig: func() { instanceID = *instance.ID },
+				Config:    testAccResourceComputeInstanceConfigClearAntiAffinityGroups,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceComputeInstanceExists(r, &instance),
+					func(s *terraform.State) error {
+						a := require.New(t)
+
+						a.NotEqual(instanceID, *instance.ID)
+						if instance.AntiAffinityGroupIDs != nil {
+							a.Empty(*instance.AntiAffinityGroupIDs)
+						}
+
+						return nil
+					},
+					checkResourceState(r, checkResourceStateValidateAttributes(testAttrs{
+						resComputeInstanceAttrAntiAffinityGroupIDs + ".#": validateString("0"),
+					})),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckResourceComputeInstanceExists(r string, instance *exov2.Instance) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[r]
+		if !ok {
+			return errors.New("resource not found in the state")
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("resource ID not set")
+		}
+
+		client := GetComputeClient(testAccProvider.Meta())
+		ctx := exoapi.WithEndpoint(
+			context.Background(),
+			exoapi.NewReqEndpoint(testEnvironment, testZoneName),
+		)
+
+		res, err := client.Client.GetInstance(ctx, testZoneName, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*instance = *res
+		return nil
+	}
+}
+
+func testAccCheckResourceComputeInstanceDestroy(instance *exov2.Instance) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		client := GetComputeClient(testAccProvider.Meta())
+		ctx := exoapi.WithEndpoint(
+			context.Background(),
+			exoapi.NewReqEndpoint(testEnvironment, testZoneName),
+		)
+
+		_, err := client.GetInstance(ctx, testZoneName, *instance.ID)
+		if err != nil {
+			if errors.Is(err, exoapi.ErrNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		return errors.New("Compute instance still exists")
+	}
+}
+
+func TestResourceComputeInstanceAntiAffinityGroupsDiff(t *testing.T) {
+	const (
+		aagID      = "6d9f6b6e-4ab5-4b61-a4b1-48d1a3a4a1e2"
+		otherAAGID = "0b8b1c4e-3f6d-4d0e-9a3e-0c8e6c2f9d11"
+	)
+
+	state := &terraform.InstanceState{
+		ID: "c4c3e8a0-1b2d-4d3e-8f4a-5b6c7d8e9f00",
+		Attributes: map[string]string{
+			"id": "c4c3e8a0-1b2d-4d3e-8f4a-5b6c7d8e9f00",
+			resComputeInstanceAttrAntiAffinityGroupIDs + ".#":                                          "1",
+			fmt.Sprintf("%s.%d", resComputeInstanceAttrAntiAffinityGroupIDs, schema.HashString(aagID)): aagID,
+			resComputeInstanceAttrDiskSize:                                                             fmt.Sprint(defaultComputeInstanceDiskSize),
+			resComputeInstanceAttrName:                                                                 "test",
+			resComputeInstanceAttrState:                                                                computeInstanceStateRunning,
+			resComputeInstanceAttrTemplateID:                                                           testInstanceTemplateID,
+			resComputeInstanceAttrType:                                                                 "standard.tiny",
+			resComputeInstanceAttrZone:                                                                 testZoneName,
+		},
+	}
+
+	config := func(aagIDs ...interface{}) *terraform.ResourceConfig {
+		c := map[string]interface{}{
+			resComputeInstanceAttrName:       "test",
+			resComputeInstanceAttrState:      computeInstanceStateRunning,
+			resComputeInstanceAttrTemplateID: testInstanceTemplateID,
+			resComputeInstanceAttrType:       "standard.tiny",
+			resComputeInstanceAttrZone:       testZoneName,
+		}
+		if len(aagIDs) > 0 {
+			c[resComputeInstanceAttrAntiAffinityGroupIDs] = aagIDs
+		}
+		return terraform.NewResourceConfigRaw(c)
+	}
+
+	// Clearing the Anti-Affinity Groups re-creates the instance, which
+	// doesn't require allow_reboot_for_resize.
+	diff, err := resourceComputeInstance().Diff(context.Background(), state, config(), nil)
+	require.NoError(t, err)
+	require.True(t, diff.RequiresNew())
+
+	// Changing them is done in place, requiring allow_reboot_for_resize on a
+	// running instance.
+	_, err = resourceComputeInstance().Diff(context.Background(), state, config(otherAAGID), nil)
+	require.EqualError(t, err, fmt.Sprintf(
+		"changing the Anti-Affinity Groups of a running Compute instance requires stopping it: set %q to true to allow it",
+		resComputeInstanceAttrAllowRebootForResize,
+	))
+}
+
+func TestComputeInstanceNetworkInterfaces(t *testing.T) {
+	set := schema.NewSet(
+		schema.HashResource(resourceComputeInstance().Schema[resComputeInstanceAttrNetworkInterface].Elem.(*schema.Resource)),
+		[]interface{}{
+			map[string]interface{}{
+				resComputeInstanceAttrNetworkInterfaceNetworkID: "a",
+				resComputeInstanceAttrNetworkInterfaceIPAddress: "10.0.0.1",
+			},
+			map[string]interface{}{
+				resComputeInstanceAttrNetworkInterfaceNetworkID: "b",
+				resComputeInstanceAttrNetworkInterfaceIPAddress: "",
+			},
+		},
+	)
+
+	require.Equal(t, map[string]string{"a": "10.0.0.1", "b": ""}, computeInstanceNetworkInterfaces(set))
+}
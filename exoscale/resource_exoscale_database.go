@@ -6,18 +6,46 @@ import (
 	"errors"
 	"fmt"
 	"log"
-
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/exoscale/egoscale"
 	exov2 "github.com/exoscale/egoscale/v2"
 	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
+	databaseServiceStateRebalancing = "rebalancing"
+	databaseServiceStateRebuilding  = "rebuilding"
+	databaseServiceStateRunning     = "running"
+
+	// databaseServiceStateUpdatePending is reported while waiting for a
+	// database service update, as long as the service is still running and
+	// hasn't been seen in a transitional state yet.
+	databaseServiceStateUpdatePending = "running (update pending)"
+
+	// databaseServiceUpdateStartTimeout is the time after which a database
+	// service still running without having been seen in a transitional state
+	// is considered to have applied the update without transitioning.
+	databaseServiceUpdateStartTimeout = 2 * time.Minute
+
+	databaseUserConfigIPFilter  = "ip_filter"
+	databaseUserConfigPGVersion = "pg_version"
+
 	resDatabaseAttrCreatedAt             = "created_at"
+	resDatabaseAttrDatabase              = "database"
 	resDatabaseAttrDiskSize              = "disk_size"
 	resDatabaseAttrFeatures              = "features"
+	resDatabaseAttrHost                  = "host"
 	resDatabaseAttrMaintenanceDOW        = "maintenance_dow"
 	resDatabaseAttrMaintenanceTime       = "maintenance_time"
 	resDatabaseAttrMetadata              = "metadata"
@@ -25,13 +53,17 @@ const (
 	resDatabaseAttrNodeCPUs              = "node_cpus"
 	resDatabaseAttrNodeMemory            = "node_memory"
 	resDatabaseAttrNodes                 = "nodes"
+	resDatabaseAttrPassword              = "password"
 	resDatabaseAttrPlan                  = "plan"
+	resDatabaseAttrPort                  = "port"
+	resDatabaseAttrSSLMode               = "sslmode"
 	resDatabaseAttrState                 = "state"
 	resDatabaseAttrTerminationProtection = "termination_protection"
 	resDatabaseAttrType                  = "type"
 	resDatabaseAttrUpdatedAt             = "updated_at"
 	resDatabaseAttrURI                   = "uri"
 	resDatabaseAttrUserConfig            = "user_config"
+	resDatabaseAttrUsername              = "username"
 	resDatabaseAttrZone                  = "zone"
 )
 
@@ -45,6 +77,10 @@ func resourceDatabase() *schema.Resource {
 			Type:     schema.TypeString,
 			Computed: true,
 		},
+		resDatabaseAttrDatabase: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
 		resDatabaseAttrDiskSize: {
 			Type:     schema.TypeInt,
 			Computed: true,
@@ -54,9 +90,15 @@ func resourceDatabase() *schema.Resource {
 			Computed: true,
 			Elem:     &schema.Schema{Type: schema.TypeString},
 		},
-		resDatabaseAttrMaintenanceDOW: {
+		resDatabaseAttrHost: {
 			Type:     schema.TypeString,
-			Optional: true,
+			Computed: true,
+		},
+		resDatabaseAttrMaintenanceDOW: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			RequiredWith: []string{resDatabaseAttrMaintenanceTime},
 			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(
 				[]string{
 					"never",
@@ -71,8 +113,14 @@ func resourceDatabase() *schema.Resource {
 				false)),
 		},
 		resDatabaseAttrMaintenanceTime: {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			RequiredWith: []string{resDatabaseAttrMaintenanceDOW},
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(
+				regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]:[0-5][0-9]$`),
+				`must be a time of day in the "HH:MM:SS" format`,
+			)),
 		},
 		resDatabaseAttrMetadata: {
 			Type:     schema.TypeMap,
@@ -95,10 +143,23 @@ func resourceDatabase() *schema.Resource {
 			Type:     schema.TypeInt,
 			Computed: true,
 		},
+		resDatabaseAttrPassword: {
+			Type:      schema.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
 		resDatabaseAttrPlan: {
 			Type:     schema.TypeString,
 			Required: true,
 		},
+		resDatabaseAttrPort: {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		resDatabaseAttrSSLMode: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
 		resDatabaseAttrState: {
 			Type:     schema.TypeString,
 			Computed: true,
@@ -118,14 +179,45 @@ func resourceDatabase() *schema.Resource {
 			Computed: true,
 		},
 		resDatabaseAttrURI: {
-			Type:      schema.TypeString,
-			Computed:  true,
-			Sensitive: true,
+			Type:     schema.TypeString,
+			Computed: true,
 		},
 		resDatabaseAttrUserConfig: {
 			Type:     schema.TypeString,
 			Optional: true,
 			Computed: true,
+			ValidateDiagFunc: func(i interface{}, path cty.Path) diag.Diagnostics {
+				if _, err := normalizeDatabaseUserConfig(i.(string)); err != nil {
+					return diag.Diagnostics{{
+						Severity:      diag.Error,
+						Summary:       "Invalid database service user configuration",
+						Detail:        err.Error(),
+						AttributePath: path,
+					}}
+				}
+				return nil
+			},
+			DiffSuppressFunc: func(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+				if oldValue == "" || newValue == "" {
+					return false
+				}
+
+				oldUserConfig, err := normalizeDatabaseUserConfig(oldValue)
+				if err != nil {
+					return false
+				}
+
+				newUserConfig, err := normalizeDatabaseUserConfig(newValue)
+				if err != nil {
+					return false
+				}
+
+				return reflect.DeepEqual(oldUserConfig, newUserConfig)
+			},
+		},
+		resDatabaseAttrUsername: {
+			Type:     schema.TypeString,
+			Computed: true,
 		},
 		resDatabaseAttrZone: {
 			Type:     schema.TypeString,
@@ -142,6 +234,8 @@ func resourceDatabase() *schema.Resource {
 		UpdateContext: resourceDatabaseUpdate,
 		DeleteContext: resourceDatabaseDelete,
 
+		CustomizeDiff: resourceDatabaseCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: zonedStateContextFunc,
 		},
@@ -198,8 +292,8 @@ func resourceDatabaseCreate(ctx context.Context, d *schema.ResourceData, meta in
 	}
 
 	if v, ok := d.GetOk(resDatabaseAttrUserConfig); ok {
-		var userConfig map[string]interface{}
-		if err := json.Unmarshal([]byte(v.(string)), &userConfig); err != nil {
+		userConfig, err := normalizeDatabaseUserConfig(v.(string))
+		if err != nil {
 			return diag.FromErr(err)
 		}
 		database.UserConfig = &userConfig
@@ -291,8 +385,8 @@ func resourceDatabaseUpdate(ctx context.Context, d *schema.ResourceData, meta in
 	}
 
 	if d.HasChange(resDatabaseAttrUserConfig) {
-		var userConfig map[string]interface{}
-		if err := json.Unmarshal([]byte(d.Get(resDatabaseAttrUserConfig).(string)), &userConfig); err != nil {
+		userConfig, err := normalizeDatabaseUserConfig(d.Get(resDatabaseAttrUserConfig).(string))
+		if err != nil {
 			return diag.FromErr(err)
 		}
 		database.UserConfig = &userConfig
@@ -303,6 +397,23 @@ func resourceDatabaseUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		if err = client.UpdateDatabaseService(ctx, zone, database); err != nil {
 			return diag.FromErr(err)
 		}
+
+		if d.HasChange(resDatabaseAttrPlan) {
+			if err = waitForDatabaseServiceRunning(ctx, d, client, zone); err != nil {
+				return diag.Errorf("error waiting for database service plan change: %s", err)
+			}
+		}
+
+		if d.HasChange(resDatabaseAttrUserConfig) {
+			o, n := d.GetChange(resDatabaseAttrUserConfig)
+			if databaseUserConfigPGVersionValue(o.(string)) != databaseUserConfigPGVersionValue(n.(string)) {
+				log.Printf("[DEBUG] %s: waiting for major version upgrade to complete", resourceDatabaseIDString(d))
+
+				if err = waitForDatabaseServiceRunning(ctx, d, client, zone); err != nil {
+					return diag.Errorf("error waiting for database service major version upgrade: %s", err)
+				}
+			}
+		}
 	}
 
 	log.Printf("[DEBUG] %s: update finished successfully", resourceDatabaseIDString(d))
@@ -321,8 +432,29 @@ func resourceDatabaseDelete(ctx context.Context, d *schema.ResourceData, meta in
 
 	client := GetComputeClient(meta)
 
-	err := client.DeleteDatabaseService(ctx, zone, d.Id())
+	database, err := client.GetDatabaseService(ctx, zone, d.Id())
 	if err != nil {
+		if errors.Is(err, exoapi.ErrNotFound) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if defaultBool(database.TerminationProtection, false) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Database service is protected against termination",
+			Detail: fmt.Sprintf(
+				"The database service %q has termination protection enabled. "+
+					"Set %q to false and apply the change before destroying it.",
+				d.Id(),
+				resDatabaseAttrTerminationProtection,
+			),
+			AttributePath: cty.GetAttrPath(resDatabaseAttrTerminationProtection),
+		}}
+	}
+
+	if err = client.DeleteDatabaseService(ctx, zone, d.Id()); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -331,6 +463,250 @@ func resourceDatabaseDelete(ctx context.Context, d *schema.ResourceData, meta in
 	return nil
 }
 
+// normalizeDatabaseUserConfig parses the JSON-formatted database service user
+// configuration, validating and normalizing its "ip_filter" entries to the CIDR
+// notation (e.g. "1.2.3.4" -> "1.2.3.4/32").
+func normalizeDatabaseUserConfig(v string) (map[string]interface{}, error) {
+	var userConfig map[string]interface{}
+	if err := json.Unmarshal([]byte(v), &userConfig); err != nil {
+		return nil, err
+	}
+
+	if v, ok := userConfig[databaseUserConfigIPFilter]; ok {
+		ipFilter, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s: expected a list of CIDRs", databaseUserConfigIPFilter)
+		}
+
+		for i, entry := range ipFilter {
+			s, ok := entry.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: expected a list of CIDRs", databaseUserConfigIPFilter)
+			}
+
+			cidr, err := normalizeCIDR(s)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", databaseUserConfigIPFilter, err)
+			}
+			ipFilter[i] = cidr
+		}
+	}
+
+	return userConfig, nil
+}
+
+// databaseUserConfigPGVersionValue returns the PostgreSQL major version set in
+// the JSON-formatted database service user configuration, or an empty string
+// if unset or if the configuration can't be parsed.
+func databaseUserConfigPGVersionValue(v string) string {
+	var userConfig map[string]interface{}
+	if err := json.Unmarshal([]byte(v), &userConfig); err != nil {
+		return ""
+	}
+
+	switch version := userConfig[databaseUserConfigPGVersion].(type) {
+	case string:
+		return version
+	case float64:
+		return strconv.FormatFloat(version, 'f', -1, 64)
+	}
+
+	return ""
+}
+
+// validateDatabasePGVersionChange checks that a change of the PostgreSQL major
+// version between the old and new user configurations is an upgrade, as the
+// API performs major version upgrades in-place and doesn't support downgrades.
+func validateDatabasePGVersionChange(o, n string) error {
+	oldVersion, err := strconv.Atoi(databaseUserConfigPGVersionValue(o))
+	if err != nil {
+		return nil
+	}
+
+	newVersion, err := strconv.Atoi(databaseUserConfigPGVersionValue(n))
+	if err != nil {
+		return nil
+	}
+
+	if newVersion < oldVersion {
+		return fmt.Errorf(
+			"%s: downgrading %s from %d to %d is not supported",
+			resDatabaseAttrUserConfig,
+			databaseUserConfigPGVersion,
+			oldVersion,
+			newVersion,
+		)
+	}
+
+	return nil
+}
+
+// databaseServiceURI represents the components of a Database Service
+// connection URI.
+type databaseServiceURI struct {
+	Database string
+	Host     string
+	Password string
+	Port     int
+	SSLMode  string
+	Username string
+}
+
+// parseDatabaseServiceURI splits a Database Service connection URI into its
+// components, so that the non-sensitive ones can be exposed separately from
+// the password. A nil URI (e.g. service not yet provisioned) yields empty
+// components.
+func parseDatabaseServiceURI(u *url.URL) (*databaseServiceURI, error) {
+	uri := databaseServiceURI{}
+	if u == nil {
+		return &uri, nil
+	}
+
+	uri.Host = u.Hostname()
+	uri.Database = strings.TrimPrefix(u.Path, "/")
+	uri.SSLMode = u.Query().Get("sslmode")
+
+	if p := u.Port(); p != "" {
+		port, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse Database Service URI port: %w", err)
+		}
+		uri.Port = port
+	}
+
+	if u.User != nil {
+		uri.Username = u.User.Username()
+		uri.Password, _ = u.User.Password()
+	}
+
+	return &uri, nil
+}
+
+// redactDatabaseServiceURI returns a Database Service connection URI without
+// the admin user password, so that it isn't stored in the state in addition to
+// the sensitive password attribute.
+func redactDatabaseServiceURI(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	redacted := *u
+	if u.User != nil {
+		redacted.User = url.User(u.User.Username())
+	}
+
+	return redacted.String()
+}
+
+// waitForDatabaseServiceRunning waits for the database service to return to
+// the "running" state, e.g. after its plan or major version has been changed.
+// As the service may still report being running right after the update
+// request, it must be seen in a transitional state first (see
+// databaseServiceWaitState).
+func waitForDatabaseServiceRunning(
+	ctx context.Context,
+	d *schema.ResourceData,
+	client *egoscale.Client,
+	zone string,
+) error {
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	progress := newWaitProgress(resourceDatabaseIDString(d), "waiting for database service to be running", timeout)
+
+	var (
+		started      = time.Now()
+		transitioned bool
+	)
+
+	_, err := (&resource.StateChangeConf{
+		Pending: []string{
+			databaseServiceStateUpdatePending,
+			databaseServiceStateRebalancing,
+			databaseServiceStateRebuilding,
+		},
+		Target: []string{databaseServiceStateRunning},
+		Refresh: func() (interface{}, string, error) {
+			database, err := client.GetDatabaseService(ctx, zone, d.Id())
+			if err != nil {
+				return nil, "", err
+			}
+
+			state := defaultString(database.State, "")
+			if state != databaseServiceStateRunning {
+				transitioned = true
+			}
+
+			log.Printf("[DEBUG] %s: state: %s", resourceDatabaseIDString(d), state)
+			progress.report(state)
+
+			return database, databaseServiceWaitState(state, transitioned, time.Since(started)), nil
+		},
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+		Timeout:    timeout,
+	}).WaitForStateContext(ctx)
+
+	return err
+}
+
+// databaseServiceWaitState returns the state to report while waiting for a
+// database service update to complete: a running service which hasn't been
+// seen in a transitional state yet is reported as having its update pending,
+// until databaseServiceUpdateStartTimeout has elapsed.
+func databaseServiceWaitState(state string, transitioned bool, elapsed time.Duration) string {
+	if state == databaseServiceStateRunning && !transitioned && elapsed < databaseServiceUpdateStartTimeout {
+		return databaseServiceStateUpdatePending
+	}
+
+	return state
+}
+
+// resourceDatabaseCustomizeDiff validates the database service user
+// configuration against the JSON schema published by the API for the
+// database service type, so that invalid settings are reported at plan time.
+func resourceDatabaseCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange(resDatabaseAttrUserConfig) ||
+		!d.NewValueKnown(resDatabaseAttrUserConfig) ||
+		!d.NewValueKnown(resDatabaseAttrType) ||
+		!d.NewValueKnown(resDatabaseAttrZone) {
+		return nil
+	}
+
+	v := d.Get(resDatabaseAttrUserConfig).(string)
+	if v == "" {
+		return nil
+	}
+
+	zone := d.Get(resDatabaseAttrZone).(string)
+	databaseType := d.Get(resDatabaseAttrType).(string)
+
+	if d.Id() != "" {
+		o, _ := d.GetChange(resDatabaseAttrUserConfig)
+		if err := validateDatabasePGVersionChange(o.(string), v); err != nil {
+			return err
+		}
+	}
+
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+
+	client := GetComputeClient(meta)
+
+	serviceType, err := client.GetDatabaseServiceType(ctx, zone, databaseType)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve database service type %q: %w", databaseType, err)
+	}
+
+	if serviceType.UserConfigSchema == nil {
+		return nil
+	}
+
+	userConfig, err := normalizeDatabaseUserConfig(v)
+	if err != nil {
+		return err
+	}
+
+	return validateJSONSchema(serviceType.UserConfigSchema, userConfig, resDatabaseAttrUserConfig)
+}
+
 func resourceDatabaseApply(
 	_ context.Context,
 	d *schema.ResourceData,
@@ -400,7 +776,36 @@ func resourceDatabaseApply(
 		return diag.FromErr(err)
 	}
 
-	if err := d.Set(resDatabaseAttrURI, database.URI.String()); err != nil {
+	if err := d.Set(resDatabaseAttrURI, redactDatabaseServiceURI(database.URI)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	uri, err := parseDatabaseServiceURI(database.URI)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resDatabaseAttrDatabase, uri.Database); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resDatabaseAttrHost, uri.Host); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resDatabaseAttrPassword, uri.Password); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resDatabaseAttrPort, uri.Port); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resDatabaseAttrSSLMode, uri.SSLMode); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resDatabaseAttrUsername, uri.Username); err != nil {
 		return diag.FromErr(err)
 	}
 
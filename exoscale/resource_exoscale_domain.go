@@ -63,7 +63,19 @@ func resourceDomainCreate(d *schema.ResourceData, meta interface{}) error {
 
 	client := GetDNSClient(meta)
 
-	domain, err := client.CreateDomain(ctx, d.Get("name").(string))
+	name := d.Get("name").(string)
+
+	domains, err := client.GetDomains(ctx)
+	if err != nil {
+		return err
+	}
+	for _, domain := range domains {
+		if domain.Name == name {
+			return nameConflictError("exoscale_domain", name, name)
+		}
+	}
+
+	domain, err := client.CreateDomain(ctx, name)
 	if err != nil {
 		return err
 	}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/exoscale/egoscale"
+	exoapi "github.com/exoscale/egoscale/v2/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -40,8 +41,25 @@ func resourceDomainRecord() *schema.Resource {
 				Required: true,
 			},
 			"content": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"content", "target_resource_id"},
+			},
+			"target_resource_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"content", "target_resource_id"},
+				RequiredWith: []string{"target_resource_zone"},
+				ValidateFunc: validation.IsUUID,
+				Description: "ID of a Network Load Balancer or an Elastic IP to set the record content to " +
+					"the current IP address of",
+			},
+			"target_resource_zone": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"target_resource_id"},
+				Description:  "Zone of the resource referenced by target_resource_id",
 			},
 			"ttl": {
 				Type:     schema.TypeInt,
@@ -59,6 +77,8 @@ func resourceDomainRecord() *schema.Resource {
 			},
 		},
 
+		CustomizeDiff: resourceDomainRecordCustomizeDiff,
+
 		Create: resourceDomainRecordCreate,
 		Read:   resourceDomainRecordRead,
 		Update: resourceDomainRecordUpdate,
@@ -84,11 +104,16 @@ func resourceDomainRecordCreate(d *schema.ResourceData, meta interface{}) error
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
 	defer cancel()
 
+	content, err := resourceDomainRecordContent(ctx, d, meta)
+	if err != nil {
+		return err
+	}
+
 	client := GetDNSClient(meta)
 
 	record, err := client.CreateRecord(ctx, d.Get("domain").(string), egoscale.DNSRecord{
 		Name:       d.Get("name").(string),
-		Content:    d.Get("content").(string),
+		Content:    content,
 		RecordType: d.Get("record_type").(string),
 		TTL:        d.Get("ttl").(int),
 		Prio:       d.Get("prio").(int),
@@ -211,13 +236,18 @@ func resourceDomainRecordUpdate(d *schema.ResourceData, meta interface{}) error
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
 	defer cancel()
 
+	content, err := resourceDomainRecordContent(ctx, d, meta)
+	if err != nil {
+		return err
+	}
+
 	client := GetDNSClient(meta)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	record, err := client.UpdateRecord(ctx, d.Get("domain").(string), egoscale.UpdateDNSRecord{
 		ID:      id,
 		Name:    d.Get("name").(string),
-		Content: d.Get("content").(string),
+		Content: content,
 		TTL:     d.Get("ttl").(int),
 		Prio:    d.Get("prio").(int),
 	})
@@ -248,6 +278,71 @@ func resourceDomainRecordDelete(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
+// resourceDomainRecordCustomizeDiff plans a content update when the record
+// targets a resource (target_resource_id) whose IP address differs from the
+// current record content, e.g. following the replacement of the resource.
+func resourceDomainRecordCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("target_resource_id") || !d.NewValueKnown("target_resource_zone") {
+		return d.SetNewComputed("content")
+	}
+
+	targetID := d.Get("target_resource_id").(string)
+	if targetID == "" {
+		return nil
+	}
+
+	ipAddress, err := resolveDomainRecordTarget(ctx, meta, d.Get("target_resource_zone").(string), targetID)
+	if err != nil {
+		return err
+	}
+
+	if d.Get("content").(string) != ipAddress {
+		return d.SetNew("content", ipAddress)
+	}
+
+	return nil
+}
+
+// resourceDomainRecordContent returns the content to set on the record, i.e.
+// either the "content" attribute value or the current IP address of the
+// resource referenced by "target_resource_id".
+func resourceDomainRecordContent(ctx context.Context, d *schema.ResourceData, meta interface{}) (string, error) {
+	targetID := d.Get("target_resource_id").(string)
+	if targetID == "" {
+		return d.Get("content").(string), nil
+	}
+
+	return resolveDomainRecordTarget(ctx, meta, d.Get("target_resource_zone").(string), targetID)
+}
+
+// resolveDomainRecordTarget returns the IP address of the Network Load Balancer
+// or Elastic IP identified by id in the specified zone.
+func resolveDomainRecordTarget(ctx context.Context, meta interface{}, zone, id string) (string, error) {
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+
+	client := GetComputeClient(meta)
+
+	nlb, err := client.GetNetworkLoadBalancer(ctx, zone, id)
+	if err == nil {
+		if nlb.IPAddress == nil {
+			return "", fmt.Errorf("Network Load Balancer %s has no IP address", id)
+		}
+		return nlb.IPAddress.String(), nil
+	} else if !isNotFoundError(err) {
+		return "", err
+	}
+
+	elasticIP, err := client.GetElasticIP(ctx, zone, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			return "", fmt.Errorf("no Network Load Balancer or Elastic IP found with ID %s in zone %s", id, zone)
+		}
+		return "", err
+	}
+
+	return elasticIP.IPAddress.String(), nil
+}
+
 func resourceDomainRecordApply(d *schema.ResourceData, record egoscale.DNSRecord) error {
 	d.SetId(strconv.FormatInt(record.ID, 10))
 	if err := d.Set("name", record.Name); err != nil {
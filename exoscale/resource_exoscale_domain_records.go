@@ -0,0 +1,318 @@
+package exoscale
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/exoscale/egoscale"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	defaultDomainRecordsRecordTTL = 3600
+
+	resDomainRecordsAttrDomain           = "domain"
+	resDomainRecordsAttrRecord           = "record"
+	resDomainRecordsAttrRecordContent    = "content"
+	resDomainRecordsAttrRecordName       = "name"
+	resDomainRecordsAttrRecordPrio       = "prio"
+	resDomainRecordsAttrRecordRecordType = "record_type"
+	resDomainRecordsAttrRecordTTL        = "ttl"
+)
+
+func resourceDomainRecordsIDString(d resourceIDStringer) string {
+	return resourceIDString(d, "exoscale_domain_records")
+}
+
+func resourceDomainRecords() *schema.Resource {
+	s := map[string]*schema.Schema{
+		resDomainRecordsAttrDomain: {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		resDomainRecordsAttrRecord: {
+			Type:     schema.TypeSet,
+			Required: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					resDomainRecordsAttrRecordContent: {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					resDomainRecordsAttrRecordName: {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					resDomainRecordsAttrRecordPrio: {
+						Type:     schema.TypeInt,
+						Optional: true,
+					},
+					resDomainRecordsAttrRecordRecordType: {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(supportedRecordTypes, false),
+					},
+					resDomainRecordsAttrRecordTTL: {
+						Type:     schema.TypeInt,
+						Optional: true,
+						Default:  defaultDomainRecordsRecordTTL,
+					},
+				},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Schema: s,
+
+		CreateContext: resourceDomainRecordsCreate,
+		ReadContext:   resourceDomainRecordsRead,
+		UpdateContext: resourceDomainRecordsUpdate,
+		DeleteContext: resourceDomainRecordsDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDomainRecordsImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+	}
+}
+
+func resourceDomainRecordsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning create", resourceDomainRecordsIDString(d))
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	client := GetDNSClient(meta)
+
+	domain := d.Get(resDomainRecordsAttrDomain).(string)
+
+	// The ID is set before creating the records, so that the records created
+	// before a failure are tracked in the state.
+	d.SetId(domain)
+
+	for _, r := range d.Get(resDomainRecordsAttrRecord).(*schema.Set).List() {
+		if _, err := client.CreateRecord(ctx, domain, expandDomainRecordsRecord(r)); err != nil {
+			return resourceDomainRecordsPartialError(ctx, d, meta, d.Get(resDomainRecordsAttrRecord).(*schema.Set), err)
+		}
+	}
+
+	log.Printf("[DEBUG] %s: create finished successfully", resourceDomainRecordsIDString(d))
+
+	return resourceDomainRecordsRead(ctx, d, meta)
+}
+
+func resourceDomainRecordsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning read", resourceDomainRecordsIDString(d))
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	client := GetDNSClient(meta)
+
+	records, err := client.GetRecords(ctx, d.Get(resDomainRecordsAttrDomain).(string))
+	if err != nil {
+		if dnserr, ok := err.(*egoscale.DNSErrorResponse); ok && strings.Contains(dnserr.Message, "not found") {
+			// Resource doesn't exist anymore, signaling the core to remove it from the state.
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// Only the declared records still existing in the domain are kept in the
+	// state, so that the missing ones are planned for re-creation.
+	declared := d.Get(resDomainRecordsAttrRecord).(*schema.Set)
+	existing := schema.NewSet(declared.F, nil)
+	for _, r := range declared.List() {
+		if findDomainRecordsRecord(records, expandDomainRecordsRecord(r)) != nil {
+			existing.Add(r)
+		}
+	}
+
+	if err := d.Set(resDomainRecordsAttrRecord, existing); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: read finished successfully", resourceDomainRecordsIDString(d))
+
+	return nil
+}
+
+func resourceDomainRecordsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning update", resourceDomainRecordsIDString(d))
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	client := GetDNSClient(meta)
+
+	domain := d.Get(resDomainRecordsAttrDomain).(string)
+
+	if d.HasChange(resDomainRecordsAttrRecord) {
+		o, n := d.GetChange(resDomainRecordsAttrRecord)
+		old := o.(*schema.Set)
+		new := n.(*schema.Set)
+
+		records, err := client.GetRecords(ctx, domain)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		// The new records are created before the old ones are deleted, so
+		// that changed records keep resolving during the update.
+		for _, r := range new.Difference(old).List() {
+			if _, err := client.CreateRecord(ctx, domain, expandDomainRecordsRecord(r)); err != nil {
+				return resourceDomainRecordsPartialError(ctx, d, meta, old.Union(new), err)
+			}
+		}
+
+		for _, r := range old.Difference(new).List() {
+			record := findDomainRecordsRecord(records, expandDomainRecordsRecord(r))
+			if record == nil {
+				continue
+			}
+
+			if err := client.DeleteRecord(ctx, domain, record.ID); err != nil {
+				return resourceDomainRecordsPartialError(ctx, d, meta, old.Union(new), err)
+			}
+		}
+	}
+
+	log.Printf("[DEBUG] %s: update finished successfully", resourceDomainRecordsIDString(d))
+
+	return resourceDomainRecordsRead(ctx, d, meta)
+}
+
+// resourceDomainRecordsPartialError handles an error occurring halfway
+// through the creation/deletion of records: the candidates records (i.e. the
+// records which may or may not exist at this point) are read back, so that the
+// state tracks all the records actually existing in the domain and the
+// operation is retried on the next apply without creating duplicates.
+func resourceDomainRecordsPartialError(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{},
+	candidates *schema.Set,
+	err error,
+) diag.Diagnostics {
+	if err := d.Set(resDomainRecordsAttrRecord, candidates); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return append(resourceDomainRecordsRead(ctx, d, meta), diag.FromErr(err)...)
+}
+
+func resourceDomainRecordsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning delete", resourceDomainRecordsIDString(d))
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	client := GetDNSClient(meta)
+
+	domain := d.Get(resDomainRecordsAttrDomain).(string)
+
+	records, err := client.GetRecords(ctx, domain)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, r := range d.Get(resDomainRecordsAttrRecord).(*schema.Set).List() {
+		record := findDomainRecordsRecord(records, expandDomainRecordsRecord(r))
+		if record == nil {
+			continue
+		}
+
+		if err := client.DeleteRecord(ctx, domain, record.ID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Printf("[DEBUG] %s: delete finished successfully", resourceDomainRecordsIDString(d))
+
+	return nil
+}
+
+// resourceDomainRecordsImport imports all the records of a domain, except the
+// default NS and SOA ones managed by Exoscale.
+func resourceDomainRecordsImport(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{},
+) ([]*schema.ResourceData, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	client := GetDNSClient(meta)
+
+	records, err := client.GetRecords(ctx, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	set := make([]interface{}, 0, len(records))
+	for _, record := range records {
+		if record.RecordType == "NS" || record.RecordType == "SOA" {
+			continue
+		}
+
+		set = append(set, map[string]interface{}{
+			resDomainRecordsAttrRecordContent:    record.Content,
+			resDomainRecordsAttrRecordName:       record.Name,
+			resDomainRecordsAttrRecordPrio:       record.Prio,
+			resDomainRecordsAttrRecordRecordType: record.RecordType,
+			resDomainRecordsAttrRecordTTL:        record.TTL,
+		})
+	}
+
+	if err := d.Set(resDomainRecordsAttrDomain, d.Id()); err != nil {
+		return nil, err
+	}
+
+	if err := d.Set(resDomainRecordsAttrRecord, set); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// expandDomainRecordsRecord converts a "record" block into a DNS record.
+func expandDomainRecordsRecord(r interface{}) egoscale.DNSRecord {
+	record := r.(map[string]interface{})
+
+	return egoscale.DNSRecord{
+		Name:       record[resDomainRecordsAttrRecordName].(string),
+		RecordType: record[resDomainRecordsAttrRecordRecordType].(string),
+		Content:    record[resDomainRecordsAttrRecordContent].(string),
+		TTL:        record[resDomainRecordsAttrRecordTTL].(int),
+		Prio:       record[resDomainRecordsAttrRecordPrio].(int),
+	}
+}
+
+// findDomainRecordsRecord returns the record of records matching the name,
+// type, content, TTL and priority of the specified record, or nil if none
+// matches.
+func findDomainRecordsRecord(records []egoscale.DNSRecord, record egoscale.DNSRecord) *egoscale.DNSRecord {
+	for i, r := range records {
+		if r.Name == record.Name &&
+			strings.EqualFold(r.RecordType, record.RecordType) &&
+			r.Content == record.Content &&
+			r.TTL == record.TTL &&
+			r.Prio == record.Prio {
+			return &records[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/exoscale/egoscale"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAccResourceDomainRecordsDomainName = acctest.RandomWithPrefix(testPrefix) + ".net"
+
+	testAccResourceDomainRecordsConfigCreate = fmt.Sprintf(`
+resource "exoscale_domain" "exo" {
+  name = "%s"
+}
+
+resource "exoscale_domain_records" "test" {
+  domain = exoscale_domain.exo.id
+
+  dynamic "record" {
+    for_each = jsondecode(<<EOT
+[
+  {"name": "www", "record_type": "A", "content": "1.2.3.4", "ttl": 300},
+  {"name": "mail", "record_type": "A", "content": "1.2.3.5", "ttl": 3600}
+]
+EOT
+    )
+
+    content {
+      name        = record.value.name
+      record_type = record.value.record_type
+      content     = record.value.content
+      ttl         = record.value.ttl
+    }
+  }
+}
+`,
+		testAccResourceDomainRecordsDomainName,
+	)
+
+	testAccResourceDomainRecordsConfigUpdate = fmt.Sprintf(`
+resource "exoscale_domain" "exo" {
+  name = "%s"
+}
+
+resource "exoscale_domain_records" "test" {
+  domain = exoscale_domain.exo.id
+
+  record {
+    name        = "www"
+    record_type = "A"
+    content     = "1.2.3.4"
+    ttl         = 300
+  }
+
+  record {
+    name        = "mail"
+    record_type = "MX"
+    content     = "mta1"
+    prio        = 10
+  }
+}
+`,
+		testAccResourceDomainRecordsDomainName,
+	)
+
+	// The "bad" record content is rejected by the API, possibly after the
+	// "ftp" record has been created.
+	testAccResourceDomainRecordsConfigPartial = `
+resource "exoscale_domain" "exo" {
+  name = "%s"
+}
+
+resource "exoscale_domain_records" "test" {
+  domain = exoscale_domain.exo.id
+
+  record {
+    name        = "www"
+    record_type = "A"
+    content     = "1.2.3.4"
+    ttl         = 300
+  }
+
+  record {
+    name        = "ftp"
+    record_type = "A"
+    content     = "1.2.3.6"
+  }
+%s}
+`
+)
+
+func TestAccResourceDomainRecords(t *testing.T) {
+	domain := new(egoscale.DNSDomain)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckResourceDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDomainRecordsConfigCreate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceDomainExists("exoscale_domain.exo", domain),
+					testAccCheckResourceDomainRecordsExist(domain, []egoscale.DNSRecord{
+						{Name: "www", RecordType: "A", Content: "1.2.3.4", TTL: 300},
+						{Name: "mail", RecordType: "A", Content: "1.2.3.5", TTL: 3600},
+					}),
+					resource.TestCheckResourceAttr("exoscale_domain_records.test", "record.#", "2"),
+				),
+			},
+			{
+				Config: testAccResourceDomainRecordsConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceDomainExists("exoscale_domain.exo", domain),
+					testAccCheckResourceDomainRecordsExist(domain, []egoscale.DNSRecord{
+						{Name: "www", RecordType: "A", Content: "1.2.3.4", TTL: 300},
+						{Name: "mail", RecordType: "MX", Content: "mta1", TTL: 3600, Prio: 10},
+					}),
+					resource.TestCheckResourceAttr("exoscale_domain_records.test", "record.#", "2"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccResourceDomainRecordsConfigPartial, testAccResourceDomainRecordsDomainName, `
+  record {
+    name        = "bad"
+    record_type = "A"
+    content     = "not-an-ip-address"
+  }
+`),
+				ExpectError: regexp.MustCompile(".*"),
+			},
+			{
+				// The records created before the failure must be tracked in
+				// the state, and not be created again.
+				Config: fmt.Sprintf(testAccResourceDomainRecordsConfigPartial, testAccResourceDomainRecordsDomainName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceDomainExists("exoscale_domain.exo", domain),
+					testAccCheckResourceDomainRecordsCount(domain, "ftp", 1),
+					testAccCheckResourceDomainRecordsCount(domain, "mail", 0),
+					resource.TestCheckResourceAttr("exoscale_domain_records.test", "record.#", "2"),
+				),
+			},
+			{
+				// Import
+				ResourceName:      "exoscale_domain_records.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestFindDomainRecordsRecord(t *testing.T) {
+	records := []egoscale.DNSRecord{
+		{ID: 1, Name: "www", RecordType: "A", Content: "1.2.3.4", TTL: 300},
+		{ID: 2, Name: "", RecordType: "MX", Content: "mta1", TTL: 3600, Prio: 10},
+	}
+
+	actual := findDomainRecordsRecord(records, egoscale.DNSRecord{
+		Name:       "www",
+		RecordType: "a",
+		Content:    "1.2.3.4",
+		TTL:        300,
+	})
+	require.NotNil(t, actual)
+	require.Equal(t, int64(1), actual.ID)
+
+	actual = findDomainRecordsRecord(records, egoscale.DNSRecord{
+		Name:       "",
+		RecordType: "MX",
+		Content:    "mta1",
+		TTL:        3600,
+		Prio:       10,
+	})
+	require.NotNil(t, actual)
+	require.Equal(t, int64(2), actual.ID)
+
+	require.Nil(t, findDomainRecordsRecord(records, egoscale.DNSRecord{
+		Name:       "www",
+		RecordType: "A",
+		Content:    "1.2.3.4",
+		TTL:        3600,
+	}))
+}
+
+func testAccCheckResourceDomainRecordsExist(domain *egoscale.DNSDomain, expected []egoscale.DNSRecord) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := GetDNSClient(testAccProvider.Meta())
+
+		records, err := client.GetRecords(context.TODO(), domain.Name)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range expected {
+			if findDomainRecordsRecord(records, record) == nil {
+				return fmt.Errorf("record %s %s %q not found", record.Name, record.RecordType, record.Content)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckResourceDomainRecordsCount(domain *egoscale.DNSDomain, name string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := GetDNSClient(testAccProvider.Meta())
+
+		records, err := client.GetRecords(context.TODO(), domain.Name)
+		if err != nil {
+			return err
+		}
+
+		var count int
+		for _, record := range records {
+			if record.Name == name {
+				count++
+			}
+		}
+
+		if count != expected {
+			return fmt.Errorf("expected %d record(s) named %q, got %d", expected, name, count)
+		}
+
+		return nil
+	}
+}
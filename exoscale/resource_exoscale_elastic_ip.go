@@ -0,0 +1,400 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/exoscale/egoscale"
+	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	defaultElasticIPHealthcheckInterval    = 10
+	defaultElasticIPHealthcheckStrikesFail = 3
+	defaultElasticIPHealthcheckStrikesOK   = 2
+	defaultElasticIPHealthcheckTimeout     = 2
+
+	resElasticIPAttrDescription              = "description"
+	resElasticIPAttrHealthcheck              = "healthcheck"
+	resElasticIPAttrHealthcheckInterval      = "interval"
+	resElasticIPAttrHealthcheckMode          = "mode"
+	resElasticIPAttrHealthcheckPort          = "port"
+	resElasticIPAttrHealthcheckStrikesFail   = "strikes_fail"
+	resElasticIPAttrHealthcheckStrikesOK     = "strikes_ok"
+	resElasticIPAttrHealthcheckTLSSNI        = "tls_sni"
+	resElasticIPAttrHealthcheckTLSSkipVerify = "tls_skip_verify"
+	resElasticIPAttrHealthcheckTimeout       = "timeout"
+	resElasticIPAttrHealthcheckURI           = "uri"
+	resElasticIPAttrIPAddress                = "ip_address"
+	resElasticIPAttrReverseDNS               = "reverse_dns"
+	resElasticIPAttrZone                     = "zone"
+)
+
+func resourceElasticIPIDString(d resourceIDStringer) string {
+	return resourceIDString(d, "exoscale_elastic_ip")
+}
+
+func resourceElasticIP() *schema.Resource {
+	s := map[string]*schema.Schema{
+		resElasticIPAttrDescription: {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		resElasticIPAttrHealthcheck: {
+			Type:     schema.TypeSet,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					resElasticIPAttrHealthcheckInterval: {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      defaultElasticIPHealthcheckInterval,
+						ValidateFunc: validation.IntBetween(5, 300),
+					},
+					resElasticIPAttrHealthcheckMode: {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice([]string{"tcp", "http", "https"}, false),
+					},
+					resElasticIPAttrHealthcheckPort: {
+						Type:         schema.TypeInt,
+						Required:     true,
+						ValidateFunc: validation.IsPortNumber,
+					},
+					resElasticIPAttrHealthcheckStrikesFail: {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      defaultElasticIPHealthcheckStrikesFail,
+						ValidateFunc: validation.IntBetween(1, 20),
+					},
+					resElasticIPAttrHealthcheckStrikesOK: {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      defaultElasticIPHealthcheckStrikesOK,
+						ValidateFunc: validation.IntBetween(1, 20),
+					},
+					resElasticIPAttrHealthcheckTLSSNI: {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					resElasticIPAttrHealthcheckTLSSkipVerify: {
+						Type:     schema.TypeBool,
+						Optional: true,
+					},
+					resElasticIPAttrHealthcheckTimeout: {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      defaultElasticIPHealthcheckTimeout,
+						ValidateFunc: validation.IntBetween(2, 60),
+					},
+					resElasticIPAttrHealthcheckURI: {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+		resElasticIPAttrIPAddress: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		resElasticIPAttrReverseDNS: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^.*\.$`), "must be a fully qualified domain name ending with a dot"),
+		},
+		resElasticIPAttrZone: {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+	}
+
+	return &schema.Resource{
+		Schema: s,
+
+		CreateContext: resourceElasticIPCreate,
+		ReadContext:   resourceElasticIPRead,
+		UpdateContext: resourceElasticIPUpdate,
+		DeleteContext: resourceElasticIPDelete,
+
+		CustomizeDiff: resourceElasticIPCustomizeDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: zonedStateContextFunc,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+	}
+}
+
+// resourceElasticIPCustomizeDiff forces the re-creation of the Elastic IP if
+// its healthcheck is removed, as a managed Elastic IP can't be converted back
+// to an unmanaged one in place.
+func resourceElasticIPCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange(resElasticIPAttrHealthcheck) {
+		return nil
+	}
+
+	if o, n := d.GetChange(resElasticIPAttrHealthcheck); o.(*schema.Set).Len() > 0 && n.(*schema.Set).Len() == 0 {
+		return d.ForceNew(resElasticIPAttrHealthcheck)
+	}
+
+	return nil
+}
+
+func resourceElasticIPCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning create", resourceElasticIPIDString(d))
+
+	zone := d.Get(resElasticIPAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	elasticIP := &exov2.ElasticIP{
+		Healthcheck: expandElasticIPHealthcheck(d.Get(resElasticIPAttrHealthcheck).(*schema.Set)),
+	}
+
+	if v, ok := d.GetOk(resElasticIPAttrDescription); ok {
+		s := v.(string)
+		elasticIP.Description = &s
+	}
+
+	elasticIP, err := client.CreateElasticIP(ctx, zone, elasticIP)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(*elasticIP.ID)
+
+	if v, ok := d.GetOk(resElasticIPAttrReverseDNS); ok {
+		if err := updateElasticIPReverseDNS(ctx, client, *elasticIP.ID, v.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Printf("[DEBUG] %s: create finished successfully", resourceElasticIPIDString(d))
+
+	return resourceElasticIPRead(ctx, d, meta)
+}
+
+func resourceElasticIPRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning read", resourceElasticIPIDString(d))
+
+	zone := d.Get(resElasticIPAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	elasticIP, err := client.GetElasticIP(ctx, zone, d.Id())
+	if err != nil {
+		if errors.Is(err, exoapi.ErrNotFound) {
+			// Resource doesn't exist anymore, signaling the core to remove it from the state.
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// Reverse DNS records are not exposed by the v2 API, falling back to the v1 API.
+	elasticIPID, err := egoscale.ParseUUID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := client.RequestWithContext(ctx, &egoscale.QueryReverseDNSForPublicIPAddress{ID: elasticIPID})
+	if err != nil {
+		return diag.Errorf("unable to retrieve reverse DNS: %s", err)
+	}
+	var reverseDNS string
+	if ip := resp.(*egoscale.IPAddress); len(ip.ReverseDNS) > 0 {
+		reverseDNS = ip.ReverseDNS[0].DomainName
+	}
+	if err := d.Set(resElasticIPAttrReverseDNS, reverseDNS); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: read finished successfully", resourceElasticIPIDString(d))
+
+	return resourceElasticIPApply(ctx, d, elasticIP)
+}
+
+func resourceElasticIPUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning update", resourceElasticIPIDString(d))
+
+	zone := d.Get(resElasticIPAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutUpdate))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	elasticIP, err := client.GetElasticIP(ctx, zone, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var updated bool
+
+	if d.HasChange(resElasticIPAttrDescription) {
+		v := d.Get(resElasticIPAttrDescription).(string)
+		elasticIP.Description = &v
+		updated = true
+	}
+
+	if d.HasChange(resElasticIPAttrHealthcheck) {
+		elasticIP.Healthcheck = expandElasticIPHealthcheck(d.Get(resElasticIPAttrHealthcheck).(*schema.Set))
+		updated = true
+	}
+
+	if updated {
+		if err = client.UpdateElasticIP(ctx, zone, elasticIP); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange(resElasticIPAttrReverseDNS) {
+		if err := updateElasticIPReverseDNS(ctx, client, d.Id(), d.Get(resElasticIPAttrReverseDNS).(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Printf("[DEBUG] %s: update finished successfully", resourceElasticIPIDString(d))
+
+	return resourceElasticIPRead(ctx, d, meta)
+}
+
+func resourceElasticIPDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning delete", resourceElasticIPIDString(d))
+
+	zone := d.Get(resElasticIPAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutDelete))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	if err := client.DeleteElasticIP(ctx, zone, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: delete finished successfully", resourceElasticIPIDString(d))
+
+	return nil
+}
+
+func resourceElasticIPApply(_ context.Context, d *schema.ResourceData, elasticIP *exov2.ElasticIP) diag.Diagnostics {
+	if err := d.Set(resElasticIPAttrDescription, defaultString(elasticIP.Description, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	healthcheck := make([]interface{}, 0)
+	if hc := elasticIP.Healthcheck; hc != nil {
+		healthcheck = append(healthcheck, map[string]interface{}{
+			resElasticIPAttrHealthcheckInterval:      int(hc.Interval.Seconds()),
+			resElasticIPAttrHealthcheckMode:          defaultString(hc.Mode, ""),
+			resElasticIPAttrHealthcheckPort:          int(*hc.Port),
+			resElasticIPAttrHealthcheckStrikesFail:   int(defaultInt64(hc.StrikesFail, 0)),
+			resElasticIPAttrHealthcheckStrikesOK:     int(defaultInt64(hc.StrikesOK, 0)),
+			resElasticIPAttrHealthcheckTLSSNI:        defaultString(hc.TLSSNI, ""),
+			resElasticIPAttrHealthcheckTLSSkipVerify: defaultBool(hc.TLSSkipVerify, false),
+			resElasticIPAttrHealthcheckTimeout:       int(hc.Timeout.Seconds()),
+			resElasticIPAttrHealthcheckURI:           defaultString(hc.URI, ""),
+		})
+	}
+	if err := d.Set(resElasticIPAttrHealthcheck, healthcheck); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resElasticIPAttrIPAddress, elasticIP.IPAddress.String()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// expandElasticIPHealthcheck converts a "healthcheck" block set into an
+// Elastic IP healthcheck, or returns nil if the set is empty.
+func expandElasticIPHealthcheck(set *schema.Set) *exov2.ElasticIPHealthcheck {
+	if set.Len() == 0 {
+		return nil
+	}
+
+	raw := set.List()[0].(map[string]interface{})
+
+	var (
+		interval      = time.Duration(raw[resElasticIPAttrHealthcheckInterval].(int)) * time.Second
+		mode          = raw[resElasticIPAttrHealthcheckMode].(string)
+		port          = uint16(raw[resElasticIPAttrHealthcheckPort].(int))
+		strikesFail   = int64(raw[resElasticIPAttrHealthcheckStrikesFail].(int))
+		strikesOK     = int64(raw[resElasticIPAttrHealthcheckStrikesOK].(int))
+		tlsSkipVerify = raw[resElasticIPAttrHealthcheckTLSSkipVerify].(bool)
+		timeout       = time.Duration(raw[resElasticIPAttrHealthcheckTimeout].(int)) * time.Second
+	)
+
+	healthcheck := &exov2.ElasticIPHealthcheck{
+		Interval:    &interval,
+		Mode:        &mode,
+		Port:        &port,
+		StrikesFail: &strikesFail,
+		StrikesOK:   &strikesOK,
+		Timeout:     &timeout,
+	}
+
+	if mode == "https" {
+		healthcheck.TLSSkipVerify = &tlsSkipVerify
+		if v := raw[resElasticIPAttrHealthcheckTLSSNI].(string); v != "" {
+			healthcheck.TLSSNI = &v
+		}
+	}
+
+	if v := raw[resElasticIPAttrHealthcheckURI].(string); v != "" {
+		healthcheck.URI = &v
+	}
+
+	return healthcheck
+}
+
+// updateElasticIPReverseDNS sets the reverse DNS record of the specified
+// Elastic IP, or deletes it if reverseDNS is empty.
+func updateElasticIPReverseDNS(ctx context.Context, client *egoscale.Client, id, reverseDNS string) error {
+	elasticIPID, err := egoscale.ParseUUID(id)
+	if err != nil {
+		return err
+	}
+
+	var req egoscale.Command = &egoscale.DeleteReverseDNSFromPublicIPAddress{ID: elasticIPID}
+	if reverseDNS != "" {
+		req = &egoscale.UpdateReverseDNSForPublicIPAddress{
+			ID:         elasticIPID,
+			DomainName: reverseDNS,
+		}
+	}
+
+	if _, err := client.RequestWithContext(ctx, req); err != nil {
+		return fmt.Errorf("unable to update reverse DNS: %s", err)
+	}
+
+	return nil
+}
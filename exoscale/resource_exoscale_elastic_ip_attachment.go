@@ -0,0 +1,220 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	resElasticIPAttachmentAttrElasticIPID = "elastic_ip_id"
+	resElasticIPAttachmentAttrInstanceID  = "instance_id"
+	resElasticIPAttachmentAttrIPAddress   = "ip_address"
+	resElasticIPAttachmentAttrZone        = "zone"
+)
+
+func resourceElasticIPAttachmentIDString(d resourceIDStringer) string {
+	return resourceIDString(d, "exoscale_elastic_ip_attachment")
+}
+
+func resourceElasticIPAttachment() *schema.Resource {
+	s := map[string]*schema.Schema{
+		resElasticIPAttachmentAttrElasticIPID: {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		resElasticIPAttachmentAttrInstanceID: {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		resElasticIPAttachmentAttrIPAddress: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		resElasticIPAttachmentAttrZone: {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+	}
+
+	return &schema.Resource{
+		Schema: s,
+
+		CreateContext: resourceElasticIPAttachmentCreate,
+		ReadContext:   resourceElasticIPAttachmentRead,
+		DeleteContext: resourceElasticIPAttachmentDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+				zonedRes, err := zonedStateContextFunc(ctx, d, nil)
+				if err != nil {
+					return nil, err
+				}
+				d = zonedRes[0]
+
+				instanceID, elasticIPID, err := parseElasticIPAttachmentID(d.Id())
+				if err != nil {
+					return nil, err
+				}
+
+				if err := d.Set(resElasticIPAttachmentAttrInstanceID, instanceID); err != nil {
+					return nil, err
+				}
+				if err := d.Set(resElasticIPAttachmentAttrElasticIPID, elasticIPID); err != nil {
+					return nil, err
+				}
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+	}
+}
+
+func resourceElasticIPAttachmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning create", resourceElasticIPAttachmentIDString(d))
+
+	zone := d.Get(resElasticIPAttachmentAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	instance, err := client.GetInstance(ctx, zone, d.Get(resElasticIPAttachmentAttrInstanceID).(string))
+	if err != nil {
+		return diag.Errorf("unable to retrieve Compute instance: %s", err)
+	}
+
+	elasticIP, err := client.GetElasticIP(ctx, zone, d.Get(resElasticIPAttachmentAttrElasticIPID).(string))
+	if err != nil {
+		return diag.Errorf("unable to retrieve Elastic IP: %s", err)
+	}
+
+	if err := instance.AttachElasticIP(ctx, elasticIP); err != nil {
+		return diag.Errorf("unable to attach Elastic IP %s: %s", *elasticIP.ID, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", *instance.ID, *elasticIP.ID))
+
+	log.Printf("[DEBUG] %s: create finished successfully", resourceElasticIPAttachmentIDString(d))
+
+	return resourceElasticIPAttachmentRead(ctx, d, meta)
+}
+
+func resourceElasticIPAttachmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning read", resourceElasticIPAttachmentIDString(d))
+
+	zone := d.Get(resElasticIPAttachmentAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	elasticIPID := d.Get(resElasticIPAttachmentAttrElasticIPID).(string)
+
+	instance, err := client.GetInstance(ctx, zone, d.Get(resElasticIPAttachmentAttrInstanceID).(string))
+	if err != nil {
+		if errors.Is(err, exoapi.ErrNotFound) {
+			// Resource doesn't exist anymore, signaling the core to remove it from the state.
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if instance.ElasticIPIDs == nil || !in(*instance.ElasticIPIDs, elasticIPID) {
+		// The Elastic IP has been detached from the Compute instance out-of-band,
+		// signaling the core to remove the attachment from the state.
+		log.Printf("[DEBUG] %s: Elastic IP not attached to the Compute instance anymore",
+			resourceElasticIPAttachmentIDString(d))
+		d.SetId("")
+		return nil
+	}
+
+	elasticIP, err := client.GetElasticIP(ctx, zone, elasticIPID)
+	if err != nil {
+		if errors.Is(err, exoapi.ErrNotFound) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: read finished successfully", resourceElasticIPAttachmentIDString(d))
+
+	return resourceElasticIPAttachmentApply(ctx, d, elasticIP)
+}
+
+func resourceElasticIPAttachmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning delete", resourceElasticIPAttachmentIDString(d))
+
+	zone := d.Get(resElasticIPAttachmentAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutDelete))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	instance, err := client.GetInstance(ctx, zone, d.Get(resElasticIPAttachmentAttrInstanceID).(string))
+	if err != nil {
+		if errors.Is(err, exoapi.ErrNotFound) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	elasticIPID := d.Get(resElasticIPAttachmentAttrElasticIPID).(string)
+
+	if instance.ElasticIPIDs != nil && in(*instance.ElasticIPIDs, elasticIPID) {
+		if err := instance.DetachElasticIP(ctx, &exov2.ElasticIP{ID: &elasticIPID}); err != nil {
+			return diag.Errorf("unable to detach Elastic IP %s: %s", elasticIPID, err)
+		}
+	}
+
+	log.Printf("[DEBUG] %s: delete finished successfully", resourceElasticIPAttachmentIDString(d))
+
+	return nil
+}
+
+func resourceElasticIPAttachmentApply(
+	_ context.Context,
+	d *schema.ResourceData,
+	elasticIP *exov2.ElasticIP,
+) diag.Diagnostics {
+	if err := d.Set(resElasticIPAttachmentAttrIPAddress, elasticIP.IPAddress.String()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// parseElasticIPAttachmentID splits an Elastic IP attachment resource ID into
+// the Compute instance and Elastic IP IDs.
+func parseElasticIPAttachmentID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf(`invalid ID %q, expected format "<INSTANCE-ID>/<ELASTIC-IP-ID>"`, id)
+	}
+
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,175 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAccResourceElasticIPAttachmentInstanceName = acctest.RandomWithPrefix(testPrefix)
+
+	testAccResourceElasticIPAttachmentConfigBase = fmt.Sprintf(`
+locals {
+  zone = "%s"
+}
+
+resource "exoscale_elastic_ip" "test" {
+  zone = local.zone
+}
+
+resource "exoscale_compute_instance" "primary" {
+  zone = local.zone
+  name = "%s-primary"
+  type = "standard.tiny"
+  template_id = "%s"
+}
+
+resource "exoscale_compute_instance" "secondary" {
+  zone = local.zone
+  name = "%s-secondary"
+  type = "standard.tiny"
+  template_id = "%s"
+}
+`,
+		testZoneName,
+		testAccResourceElasticIPAttachmentInstanceName,
+		testInstanceTemplateID,
+		testAccResourceElasticIPAttachmentInstanceName,
+		testInstanceTemplateID,
+	)
+
+	testAccResourceElasticIPAttachmentConfigCreate = testAccResourceElasticIPAttachmentConfigBase + `
+resource "exoscale_elastic_ip_attachment" "test" {
+  zone = local.zone
+  elastic_ip_id = exoscale_elastic_ip.test.id
+  instance_id = exoscale_compute_instance.primary.id
+}
+`
+
+	testAccResourceElasticIPAttachmentConfigUpdate = testAccResourceElasticIPAttachmentConfigBase + `
+resource "exoscale_elastic_ip_attachment" "test" {
+  zone = local.zone
+  elastic_ip_id = exoscale_elastic_ip.test.id
+  instance_id = exoscale_compute_instance.secondary.id
+}
+`
+)
+
+func TestAccResourceElasticIPAttachment(t *testing.T) {
+	var (
+		r                 = "exoscale_elastic_ip_attachment.test"
+		elasticIP         exov2.ElasticIP
+		primaryInstance   exov2.Instance
+		secondaryInstance exov2.Instance
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckResourceElasticIPAttachmentDestroy(&secondaryInstance, &elasticIP),
+		Steps: []resource.TestStep{
+			{
+				// Create
+				Config: testAccResourceElasticIPAttachmentConfigCreate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceElasticIPExists("exoscale_elastic_ip.test", &elasticIP),
+					testAccCheckResourceComputeInstanceExists("exoscale_compute_instance.primary", &primaryInstance),
+					func(s *terraform.State) error {
+						a := require.New(t)
+
+						a.NotNil(primaryInstance.ElasticIPIDs)
+						a.Contains(*primaryInstance.ElasticIPIDs, *elasticIP.ID)
+
+						return nil
+					},
+					checkResourceState(r, checkResourceStateValidateAttributes(testAttrs{
+						resElasticIPAttachmentAttrElasticIPID: validation.ToDiagFunc(validation.IsUUID),
+						resElasticIPAttachmentAttrInstanceID:  validation.ToDiagFunc(validation.IsUUID),
+						resElasticIPAttachmentAttrIPAddress:   validation.ToDiagFunc(validation.IsIPv4Address),
+						resElasticIPAttachmentAttrZone:        validateString(testZoneName),
+					})),
+				),
+			},
+			{
+				// Update (move the Elastic IP to the secondary Compute instance)
+				Config: testAccResourceElasticIPAttachmentConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceComputeInstanceExists("exoscale_compute_instance.primary", &primaryInstance),
+					testAccCheckResourceComputeInstanceExists("exoscale_compute_instance.secondary", &secondaryInstance),
+					func(s *terraform.State) error {
+						a := require.New(t)
+
+						if primaryInstance.ElasticIPIDs != nil {
+							a.NotContains(*primaryInstance.ElasticIPIDs, *elasticIP.ID)
+						}
+						a.NotNil(secondaryInstance.ElasticIPIDs)
+						a.Contains(*secondaryInstance.ElasticIPIDs, *elasticIP.ID)
+
+						return nil
+					},
+				),
+			},
+			{
+				// Import
+				ResourceName: r,
+				ImportStateIdFunc: func(instance *exov2.Instance, elasticIP *exov2.ElasticIP) resource.ImportStateIdFunc {
+					return func(*terraform.State) (string, error) {
+						return fmt.Sprintf("%s/%s@%s", *instance.ID, *elasticIP.ID, testZoneName), nil
+					}
+				}(&secondaryInstance, &elasticIP),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestParseElasticIPAttachmentID(t *testing.T) {
+	instanceID, elasticIPID, err := parseElasticIPAttachmentID("a/b")
+	require.NoError(t, err)
+	require.Equal(t, "a", instanceID)
+	require.Equal(t, "b", elasticIPID)
+
+	for _, id := range []string{"", "a", "a/", "/b"} {
+		_, _, err := parseElasticIPAttachmentID(id)
+		require.Error(t, err, id)
+	}
+}
+
+func testAccCheckResourceElasticIPAttachmentDestroy(
+	instance *exov2.Instance,
+	elasticIP *exov2.ElasticIP,
+) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		client := GetComputeClient(testAccProvider.Meta())
+		ctx := exoapi.WithEndpoint(
+			context.Background(),
+			exoapi.NewReqEndpoint(testEnvironment, testZoneName),
+		)
+
+		res, err := client.GetInstance(ctx, testZoneName, *instance.ID)
+		if err != nil {
+			if errors.Is(err, exoapi.ErrNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		if res.ElasticIPIDs != nil && in(*res.ElasticIPIDs, *elasticIP.ID) {
+			return errors.New("Elastic IP still attached to the Compute instance")
+		}
+
+		return nil
+	}
+}
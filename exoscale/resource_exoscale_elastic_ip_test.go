@@ -0,0 +1,227 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAccResourceElasticIPDescription        = acctest.RandString(10)
+	testAccResourceElasticIPDescriptionUpdated = testAccResourceElasticIPDescription + "-updated"
+	testAccResourceElasticIPReverseDNS         = "test.example.com."
+
+	testAccResourceElasticIPConfigCreate = fmt.Sprintf(`
+resource "exoscale_elastic_ip" "test" {
+  zone = "%s"
+  description = "%s"
+  reverse_dns = "%s"
+
+  healthcheck {
+    mode = "https"
+    port = 443
+    uri = "/health"
+    interval = 5
+    timeout = 3
+    strikes_ok = 1
+    strikes_fail = 2
+    tls_sni = "example.net"
+    tls_skip_verify = true
+  }
+}
+`,
+		testZoneName,
+		testAccResourceElasticIPDescription,
+		testAccResourceElasticIPReverseDNS,
+	)
+
+	testAccResourceElasticIPConfigUpdate = fmt.Sprintf(`
+resource "exoscale_elastic_ip" "test" {
+  zone = "%s"
+  description = "%s"
+
+  healthcheck {
+    mode = "http"
+    port = 8080
+    uri = "/healthz"
+  }
+}
+`,
+		testZoneName,
+		testAccResourceElasticIPDescriptionUpdated,
+	)
+)
+
+func TestAccResourceElasticIP(t *testing.T) {
+	var (
+		r         = "exoscale_elastic_ip.test"
+		elasticIP exov2.ElasticIP
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckResourceElasticIPDestroy(&elasticIP),
+		Steps: []resource.TestStep{
+			{
+				// Create
+				Config: testAccResourceElasticIPConfigCreate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceElasticIPExists(r, &elasticIP),
+					func(s *terraform.State) error {
+						a := require.New(t)
+
+						a.Equal(testAccResourceElasticIPDescription, *elasticIP.Description)
+						a.NotNil(elasticIP.Healthcheck)
+						a.Equal("https", *elasticIP.Healthcheck.Mode)
+						a.Equal(uint16(443), *elasticIP.Healthcheck.Port)
+						a.Equal(5*time.Second, *elasticIP.Healthcheck.Interval)
+						a.Equal("example.net", *elasticIP.Healthcheck.TLSSNI)
+						a.True(*elasticIP.Healthcheck.TLSSkipVerify)
+
+						return nil
+					},
+					checkResourceState(r, checkResourceStateValidateAttributes(testAttrs{
+						resElasticIPAttrDescription:        validateString(testAccResourceElasticIPDescription),
+						resElasticIPAttrHealthcheck + ".#": validateString("1"),
+						resElasticIPAttrIPAddress:          validation.ToDiagFunc(validation.IsIPv4Address),
+						resElasticIPAttrReverseDNS:         validateString(testAccResourceElasticIPReverseDNS),
+						resElasticIPAttrZone:               validateString(testZoneName),
+					})),
+				),
+			},
+			{
+				// Update
+				Config: testAccResourceElasticIPConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceElasticIPExists(r, &elasticIP),
+					func(s *terraform.State) error {
+						a := require.New(t)
+
+						a.Equal(testAccResourceElasticIPDescriptionUpdated, *elasticIP.Description)
+						a.Equal("http", *elasticIP.Healthcheck.Mode)
+						a.Equal(uint16(8080), *elasticIP.Healthcheck.Port)
+						a.Equal("/healthz", *elasticIP.Healthcheck.URI)
+
+						return nil
+					},
+					checkResourceState(r, checkResourceStateValidateAttributes(testAttrs{
+						resElasticIPAttrDescription:        validateString(testAccResourceElasticIPDescriptionUpdated),
+						resElasticIPAttrHealthcheck + ".#": validateString("1"),
+						resElasticIPAttrIPAddress:          validation.ToDiagFunc(validation.IsIPv4Address),
+						resElasticIPAttrReverseDNS:         validation.ToDiagFunc(validation.StringIsEmpty),
+					})),
+				),
+			},
+			{
+				// Import
+				ResourceName: r,
+				ImportStateIdFunc: func(elasticIP *exov2.ElasticIP) resource.ImportStateIdFunc {
+					return func(*terraform.State) (string, error) {
+						return fmt.Sprintf("%s@%s", *elasticIP.ID, testZoneName), nil
+					}
+				}(&elasticIP),
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateCheck: func(s []*terraform.InstanceState) error {
+					return checkResourceAttributes(
+						testAttrs{
+							resElasticIPAttrDescription: validateString(testAccResourceElasticIPDescriptionUpdated),
+							resElasticIPAttrIPAddress:   validation.ToDiagFunc(validation.IsIPv4Address),
+							resElasticIPAttrZone:        validateString(testZoneName),
+						},
+						s[0].Attributes)
+				},
+			},
+		},
+	})
+}
+
+func TestExpandElasticIPHealthcheck(t *testing.T) {
+	f := schema.HashResource(resourceElasticIP().Schema[resElasticIPAttrHealthcheck].Elem.(*schema.Resource))
+
+	require.Nil(t, expandElasticIPHealthcheck(schema.NewSet(f, nil)))
+
+	healthcheck := expandElasticIPHealthcheck(schema.NewSet(f, []interface{}{
+		map[string]interface{}{
+			resElasticIPAttrHealthcheckInterval:      10,
+			resElasticIPAttrHealthcheckMode:          "http",
+			resElasticIPAttrHealthcheckPort:          80,
+			resElasticIPAttrHealthcheckStrikesFail:   3,
+			resElasticIPAttrHealthcheckStrikesOK:     2,
+			resElasticIPAttrHealthcheckTLSSNI:        "example.net",
+			resElasticIPAttrHealthcheckTLSSkipVerify: true,
+			resElasticIPAttrHealthcheckTimeout:       2,
+			resElasticIPAttrHealthcheckURI:           "/health",
+		},
+	}))
+	require.NotNil(t, healthcheck)
+	require.Equal(t, 10*time.Second, *healthcheck.Interval)
+	require.Equal(t, "http", *healthcheck.Mode)
+	require.Equal(t, uint16(80), *healthcheck.Port)
+	require.Equal(t, 2*time.Second, *healthcheck.Timeout)
+	require.Equal(t, "/health", *healthcheck.URI)
+
+	// TLS settings only apply to the "https" mode.
+	require.Nil(t, healthcheck.TLSSNI)
+	require.Nil(t, healthcheck.TLSSkipVerify)
+}
+
+func testAccCheckResourceElasticIPExists(r string, elasticIP *exov2.ElasticIP) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[r]
+		if !ok {
+			return errors.New("resource not found in the state")
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("resource ID not set")
+		}
+
+		client := GetComputeClient(testAccProvider.Meta())
+		ctx := exoapi.WithEndpoint(
+			context.Background(),
+			exoapi.NewReqEndpoint(testEnvironment, testZoneName),
+		)
+
+		res, err := client.Client.GetElasticIP(ctx, testZoneName, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*elasticIP = *res
+		return nil
+	}
+}
+
+func testAccCheckResourceElasticIPDestroy(elasticIP *exov2.ElasticIP) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		client := GetComputeClient(testAccProvider.Meta())
+		ctx := exoapi.WithEndpoint(
+			context.Background(),
+			exoapi.NewReqEndpoint(testEnvironment, testZoneName),
+		)
+
+		_, err := client.GetElasticIP(ctx, testZoneName, *elasticIP.ID)
+		if err != nil {
+			if errors.Is(err, exoapi.ErrNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		return errors.New("Elastic IP still exists")
+	}
+}
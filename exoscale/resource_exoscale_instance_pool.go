@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 
 	"github.com/exoscale/egoscale"
@@ -18,25 +19,30 @@ import (
 const (
 	defaultInstancePoolInstancePrefix = "pool"
 
-	resInstancePoolAttrAffinityGroupIDs = "affinity_group_ids"
-	resInstancePoolAttrDeployTargetID   = "deploy_target_id"
-	resInstancePoolAttrDescription      = "description"
-	resInstancePoolAttrDiskSize         = "disk_size"
-	resInstancePoolAttrElasticIPIDs     = "elastic_ip_ids"
-	resInstancePoolAttrInstancePrefix   = "instance_prefix"
-	resInstancePoolAttrInstanceType     = "instance_type"
-	resInstancePoolAttrIPv6             = "ipv6"
-	resInstancePoolAttrKeyPair          = "key_pair"
-	resInstancePoolAttrName             = "name"
-	resInstancePoolAttrNetworkIDs       = "network_ids"
-	resInstancePoolAttrSecurityGroupIDs = "security_group_ids"
-	resInstancePoolAttrServiceOffering  = "service_offering"
-	resInstancePoolAttrSize             = "size"
-	resInstancePoolAttrState            = "state"
-	resInstancePoolAttrTemplateID       = "template_id"
-	resInstancePoolAttrUserData         = "user_data"
-	resInstancePoolAttrVirtualMachines  = "virtual_machines"
-	resInstancePoolAttrZone             = "zone"
+	resInstancePoolAttrAffinityGroupIDs     = "affinity_group_ids"
+	resInstancePoolAttrAllowRebootForResize = "allow_reboot_for_resize"
+	resInstancePoolAttrAutoUpdateTemplate   = "auto_update_template"
+	resInstancePoolAttrDeployTargetID       = "deploy_target_id"
+	resInstancePoolAttrDescription          = "description"
+	resInstancePoolAttrDiskSize             = "disk_size"
+	resInstancePoolAttrElasticIPIDs         = "elastic_ip_ids"
+	resInstancePoolAttrInstancePrefix       = "instance_prefix"
+	resInstancePoolAttrInstanceType         = "instance_type"
+	resInstancePoolAttrIPv6                 = "ipv6"
+	resInstancePoolAttrKeyPair              = "key_pair"
+	resInstancePoolAttrLabels               = "labels"
+	resInstancePoolAttrName                 = "name"
+	resInstancePoolAttrNetworkIDs           = "network_ids"
+	resInstancePoolAttrPropagateLabels      = "propagate_labels"
+	resInstancePoolAttrSecurityGroupIDs     = "security_group_ids"
+	resInstancePoolAttrServiceOffering      = "service_offering"
+	resInstancePoolAttrSize                 = "size"
+	resInstancePoolAttrState                = "state"
+	resInstancePoolAttrTemplateID           = "template_id"
+	resInstancePoolAttrTemplateNameFilter   = "template_name_filter"
+	resInstancePoolAttrUserData             = "user_data"
+	resInstancePoolAttrVirtualMachines      = "virtual_machines"
+	resInstancePoolAttrZone                 = "zone"
 )
 
 func resourceInstancePoolIDString(d resourceIDStringer) string {
@@ -51,6 +57,17 @@ func resourceInstancePool() *schema.Resource {
 			Set:      schema.HashString,
 			Elem:     &schema.Schema{Type: schema.TypeString},
 		},
+		resInstancePoolAttrAllowRebootForResize: {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		resInstancePoolAttrAutoUpdateTemplate: {
+			Type:         schema.TypeBool,
+			Optional:     true,
+			RequiredWith: []string{resInstancePoolAttrTemplateNameFilter},
+			Description: "Update the Instance Pool to the latest template matching template_name_filter " +
+				"on every apply",
+		},
 		resInstancePoolAttrDeployTargetID: {
 			Type:     schema.TypeString,
 			Optional: true,
@@ -96,6 +113,11 @@ func resourceInstancePool() *schema.Resource {
 			Type:     schema.TypeString,
 			Optional: true,
 		},
+		resInstancePoolAttrLabels: {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
 		resInstancePoolAttrName: {
 			Type:     schema.TypeString,
 			Required: true,
@@ -106,6 +128,11 @@ func resourceInstancePool() *schema.Resource {
 			Set:      schema.HashString,
 			Elem:     &schema.Schema{Type: schema.TypeString},
 		},
+		resInstancePoolAttrPropagateLabels: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Apply the Instance Pool labels to its member Compute instances",
+		},
 		resInstancePoolAttrSecurityGroupIDs: {
 			Type:     schema.TypeSet,
 			Optional: true,
@@ -137,8 +164,17 @@ func resourceInstancePool() *schema.Resource {
 			Computed: true,
 		},
 		resInstancePoolAttrTemplateID: {
-			Type:     schema.TypeString,
-			Required: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{resInstancePoolAttrTemplateID, resInstancePoolAttrTemplateNameFilter},
+		},
+		resInstancePoolAttrTemplateNameFilter: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsValidRegExp,
+			Description: "Regular expression matching the names of the templates to use, " +
+				"the most recently created one being selected",
 		},
 		resInstancePoolAttrUserData: {
 			Type:     schema.TypeString,
@@ -166,6 +202,8 @@ func resourceInstancePool() *schema.Resource {
 		UpdateContext: resourceInstancePoolUpdate,
 		DeleteContext: resourceInstancePoolDelete,
 
+		CustomizeDiff: resourceInstancePoolCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: zonedStateContextFunc,
 		},
@@ -179,6 +217,87 @@ func resourceInstancePool() *schema.Resource {
 	}
 }
 
+// resourceInstancePoolCustomizeDiff rejects decreasing the disk size of the
+// Instance Pool if the existing members disks are to be resized (which can
+// only grow), and resolves the template ID from the template_name_filter
+// attribute if set: on creation, when the filter changes, or on every plan if
+// auto_update_template is enabled.
+func resourceInstancePoolCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" &&
+		d.HasChange(resInstancePoolAttrDiskSize) &&
+		d.Get(resInstancePoolAttrAllowRebootForResize).(bool) {
+		o, n := d.GetChange(resInstancePoolAttrDiskSize)
+		if n.(int) < o.(int) {
+			return fmt.Errorf(
+				"disk size can only be increased when %q is set (from %dGB to %dGB is not allowed)",
+				resInstancePoolAttrAllowRebootForResize, o.(int), n.(int))
+		}
+	}
+
+	filter, ok := d.GetOk(resInstancePoolAttrTemplateNameFilter)
+	if !ok {
+		return nil
+	}
+
+	if d.Id() != "" &&
+		!d.HasChange(resInstancePoolAttrTemplateNameFilter) &&
+		!d.Get(resInstancePoolAttrAutoUpdateTemplate).(bool) {
+		return nil
+	}
+
+	zone := d.Get(resInstancePoolAttrZone).(string)
+
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+
+	client := GetComputeClient(meta)
+
+	templates := make([]*exov2.Template, 0)
+	for _, visibility := range []string{"public", "private"} {
+		list, err := client.ListTemplates(ctx, zone, visibility, "")
+		if err != nil {
+			return fmt.Errorf("unable to list templates: %w", err)
+		}
+		templates = append(templates, list...)
+	}
+
+	template, err := findLatestTemplate(templates, regexp.MustCompile(filter.(string)))
+	if err != nil {
+		return err
+	}
+
+	if *template.ID != d.Get(resInstancePoolAttrTemplateID).(string) {
+		log.Printf("[DEBUG] %s: resolved template %q (%s)",
+			resourceInstancePoolIDString(d),
+			*template.Name,
+			*template.ID)
+
+		return d.SetNew(resInstancePoolAttrTemplateID, *template.ID)
+	}
+
+	return nil
+}
+
+// findLatestTemplate returns the most recently created template whose name
+// matches the specified regular expression.
+func findLatestTemplate(templates []*exov2.Template, filter *regexp.Regexp) (*exov2.Template, error) {
+	var latest *exov2.Template
+	for _, template := range templates {
+		if template.ID == nil || template.CreatedAt == nil || !filter.MatchString(defaultString(template.Name, "")) {
+			continue
+		}
+
+		if latest == nil || template.CreatedAt.After(*latest.CreatedAt) {
+			latest = template
+		}
+	}
+
+	if latest == nil {
+		return nil, fmt.Errorf("no template name matches %q", filter.String())
+	}
+
+	return latest, nil
+}
+
 func resourceInstancePoolCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[DEBUG] %s: beginning create", resourceInstancePoolIDString(d))
 
@@ -246,7 +365,7 @@ func resourceInstancePoolCreate(ctx context.Context, d *schema.ResourceData, met
 		it = v.(string)
 	}
 
-	instanceType, err := client.FindInstanceType(ctx, zone, it)
+	instanceType, err := findInstanceType(ctx, client, zone, it)
 	if err != nil {
 		return diag.Errorf("error retrieving instance type: %s", err)
 	}
@@ -307,6 +426,14 @@ func resourceInstancePoolCreate(ctx context.Context, d *schema.ResourceData, met
 	enableIPv6 := d.Get(resInstancePoolAttrIPv6).(bool)
 	instancePool.IPv6Enabled = &enableIPv6
 
+	if l, ok := d.GetOk(resInstancePoolAttrLabels); ok {
+		labels := make(map[string]string)
+		for k, v := range l.(map[string]interface{}) {
+			labels[k] = v.(string)
+		}
+		instancePool.Labels = &labels
+	}
+
 	if v := d.Get(resInstancePoolAttrUserData).(string); v != "" {
 		userData, err := encodeUserData(v)
 		if err != nil {
@@ -325,6 +452,12 @@ func resourceInstancePoolCreate(ctx context.Context, d *schema.ResourceData, met
 	}
 	d.SetId(*instancePool.ID)
 
+	if d.Get(resInstancePoolAttrPropagateLabels).(bool) {
+		if err := propagateInstancePoolLabels(ctx, client, zone, d, nil); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	log.Printf("[DEBUG] %s: create finished successfully", resourceInstancePoolIDString(d))
 
 	return resourceInstancePoolRead(ctx, d, meta)
@@ -351,6 +484,18 @@ func resourceInstancePoolRead(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(err)
 	}
 
+	// Members created outside of Terraform (e.g. replaced after a failure, or
+	// added by scaling the Instance Pool using the API) don't get the labels:
+	// report propagate_labels as disabled in this case, so that the drift
+	// shows up in the plan and the labels are propagated on the next apply.
+	if d.Get(resInstancePoolAttrPropagateLabels).(bool) &&
+		instancePoolMembersLabelsDrifted(ctx, client, zone, instancePool) {
+		log.Printf("[DEBUG] %s: labels not propagated to some members", resourceInstancePoolIDString(d))
+		if err := d.Set(resInstancePoolAttrPropagateLabels, false); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	log.Printf("[DEBUG] %s: read finished successfully", resourceInstancePoolIDString(d))
 
 	return resourceInstancePoolApply(ctx, GetComputeClient(meta), d, instancePool)
@@ -465,7 +610,7 @@ func resourceInstancePoolUpdate(ctx context.Context, d *schema.ResourceData, met
 	}
 
 	if d.HasChange(resInstancePoolAttrInstanceType) {
-		instanceType, err := client.FindInstanceType(ctx, zone, d.Get(resInstancePoolAttrInstanceType).(string))
+		instanceType, err := findInstanceType(ctx, client, zone, d.Get(resInstancePoolAttrInstanceType).(string))
 		if err != nil {
 			return diag.Errorf("error retrieving instance type: %s", err)
 		}
@@ -473,6 +618,15 @@ func resourceInstancePoolUpdate(ctx context.Context, d *schema.ResourceData, met
 		updated = true
 	}
 
+	if d.HasChange(resInstancePoolAttrLabels) {
+		labels := make(map[string]string)
+		for k, v := range d.Get(resInstancePoolAttrLabels).(map[string]interface{}) {
+			labels[k] = v.(string)
+		}
+		instancePool.Labels = &labels
+		updated = true
+	}
+
 	if d.HasChange(resInstancePoolAttrTemplateID) {
 		v := d.Get(resInstancePoolAttrTemplateID).(string)
 		instancePool.TemplateID = &v
@@ -494,12 +648,28 @@ func resourceInstancePoolUpdate(ctx context.Context, d *schema.ResourceData, met
 		}
 	}
 
+	// Without allow_reboot_for_resize, only the members created from now on
+	// get the new disk size.
+	if d.HasChange(resInstancePoolAttrDiskSize) && d.Get(resInstancePoolAttrAllowRebootForResize).(bool) {
+		if err := resizeInstancePoolMembersDisk(ctx, client, zone, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if d.HasChange(resInstancePoolAttrSize) {
 		if err = instancePool.Scale(ctx, int64(d.Get(resInstancePoolAttrSize).(int))); err != nil {
 			return diag.FromErr(err)
 		}
 	}
 
+	if d.Get(resInstancePoolAttrPropagateLabels).(bool) &&
+		d.HasChanges(resInstancePoolAttrLabels, resInstancePoolAttrPropagateLabels, resInstancePoolAttrSize) {
+		o, _ := d.GetChange(resInstancePoolAttrLabels)
+		if err := propagateInstancePoolLabels(ctx, client, zone, d, o.(map[string]interface{})); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	log.Printf("[DEBUG] %s: update finished successfully", resourceInstancePoolIDString(d))
 
 	return resourceInstancePoolRead(ctx, d, meta)
@@ -571,6 +741,14 @@ func resourceInstancePoolApply(ctx context.Context, client *egoscale.Client, d *
 		return diag.FromErr(err)
 	}
 
+	if instancePool.Labels != nil {
+		if err := d.Set(resInstancePoolAttrLabels, *instancePool.Labels); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if err := d.Set(resInstancePoolAttrLabels, map[string]string{}); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err := d.Set(resInstancePoolAttrName, instancePool.Name); err != nil {
 		return diag.FromErr(err)
 	}
@@ -648,3 +826,175 @@ func resourceInstancePoolApply(ctx context.Context, client *egoscale.Client, d *
 
 	return nil
 }
+
+// propagateInstancePoolLabels applies the Instance Pool labels to its member
+// Compute instances. Labels previously set on the Instance Pool (oldLabels)
+// and since removed from it are also removed from the members.
+func propagateInstancePoolLabels(
+	ctx context.Context,
+	client *egoscale.Client,
+	zone string,
+	d *schema.ResourceData,
+	oldLabels map[string]interface{},
+) error {
+	instancePool, err := client.GetInstancePool(ctx, zone, d.Id())
+	if err != nil {
+		return err
+	}
+	if instancePool.InstanceIDs == nil {
+		return nil
+	}
+
+	toStringMap := func(m map[string]interface{}) map[string]string {
+		res := make(map[string]string, len(m))
+		for k, v := range m {
+			res[k] = v.(string)
+		}
+		return res
+	}
+	poolLabels := toStringMap(d.Get(resInstancePoolAttrLabels).(map[string]interface{}))
+	oldPoolLabels := toStringMap(oldLabels)
+
+	for _, id := range *instancePool.InstanceIDs {
+		instance, err := client.GetInstance(ctx, zone, id)
+		if err != nil {
+			return fmt.Errorf("unable to retrieve Compute instance %s: %w", id, err)
+		}
+
+		var instanceLabels map[string]string
+		if instance.Labels != nil {
+			instanceLabels = *instance.Labels
+		}
+
+		labels, changed := mergeInstancePoolLabels(instanceLabels, oldPoolLabels, poolLabels)
+		if !changed {
+			continue
+		}
+
+		log.Printf("[DEBUG] %s: propagating labels to Compute instance %s", resourceInstancePoolIDString(d), id)
+
+		if err := client.UpdateInstance(ctx, zone, &exov2.Instance{ID: &id, Labels: &labels}); err != nil {
+			return fmt.Errorf("unable to update Compute instance %s labels: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// instancePoolMembersLabelsDrifted returns true if any member Compute instance
+// of the Instance Pool lacks some of the Instance Pool labels. Members which
+// can't be retrieved (e.g. being deleted) are ignored.
+func instancePoolMembersLabelsDrifted(
+	ctx context.Context,
+	client *egoscale.Client,
+	zone string,
+	instancePool *exov2.InstancePool,
+) bool {
+	if instancePool.InstanceIDs == nil || instancePool.Labels == nil {
+		return false
+	}
+
+	for _, id := range *instancePool.InstanceIDs {
+		instance, err := client.GetInstance(ctx, zone, id)
+		if err != nil {
+			log.Printf("[WARN] unable to retrieve Compute instance %s: %s", id, err)
+			continue
+		}
+
+		var instanceLabels map[string]string
+		if instance.Labels != nil {
+			instanceLabels = *instance.Labels
+		}
+
+		if _, changed := mergeInstancePoolLabels(instanceLabels, nil, *instancePool.Labels); changed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resizeInstancePoolMembersDisk resizes the disk of the existing Instance Pool
+// member Compute instances to the Instance Pool disk size, one instance at a
+// time: each running instance is stopped before being resized, and started
+// again afterwards.
+func resizeInstancePoolMembersDisk(
+	ctx context.Context,
+	client *egoscale.Client,
+	zone string,
+	d *schema.ResourceData,
+) error {
+	instancePool, err := client.GetInstancePool(ctx, zone, d.Id())
+	if err != nil {
+		return err
+	}
+	if instancePool.InstanceIDs == nil {
+		return nil
+	}
+
+	diskSize := int64(d.Get(resInstancePoolAttrDiskSize).(int))
+
+	for _, id := range *instancePool.InstanceIDs {
+		instance, err := client.GetInstance(ctx, zone, id)
+		if err != nil {
+			return fmt.Errorf("unable to retrieve Compute instance %s: %w", id, err)
+		}
+
+		if defaultInt64(instance.DiskSize, 0) >= diskSize {
+			continue
+		}
+
+		log.Printf("[DEBUG] %s: resizing Compute instance %s disk", resourceInstancePoolIDString(d), id)
+
+		running := defaultString(instance.State, "") == computeInstanceStateRunning
+		if running {
+			if err := instance.Stop(ctx); err != nil {
+				return fmt.Errorf("unable to stop Compute instance %s: %w", id, err)
+			}
+		}
+
+		if err := instance.ResizeDisk(ctx, diskSize); err != nil {
+			return fmt.Errorf("unable to resize Compute instance %s disk: %w", id, err)
+		}
+
+		if running {
+			if err := instance.Start(ctx); err != nil {
+				return fmt.Errorf("unable to start Compute instance %s: %w", id, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// mergeInstancePoolLabels returns the labels of a member Compute instance
+// updated with the Instance Pool labels, the ones removed from the Instance
+// Pool since oldPoolLabels being removed too if they haven't been changed on
+// the instance. The second return value reports whether the labels changed.
+func mergeInstancePoolLabels(instanceLabels, oldPoolLabels, poolLabels map[string]string) (map[string]string, bool) {
+	labels := make(map[string]string, len(instanceLabels)+len(poolLabels))
+	for k, v := range instanceLabels {
+		labels[k] = v
+	}
+
+	var changed bool
+
+	for k, v := range oldPoolLabels {
+		if _, ok := poolLabels[k]; ok {
+			continue
+		}
+		if lv, ok := labels[k]; ok && lv == v {
+			delete(labels, k)
+			changed = true
+		}
+	}
+
+	for k, v := range poolLabels {
+		if lv, ok := labels[k]; !ok || lv != v {
+			labels[k] = v
+			changed = true
+		}
+	}
+
+	return labels, changed
+}
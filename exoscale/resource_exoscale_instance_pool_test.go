@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
@@ -23,6 +24,7 @@ var (
 	testAccResourceInstancePoolDiskSize              int64 = 10
 	testAccResourceInstancePoolDiskSizeUpdated             = testAccResourceInstancePoolDiskSize * 2
 	testAccResourceInstancePoolKeyPair                     = acctest.RandomWithPrefix(testPrefix)
+	testAccResourceInstancePoolLabelValue                  = acctest.RandomWithPrefix(testPrefix)
 	testAccResourceInstancePoolName                        = acctest.RandomWithPrefix(testPrefix)
 	testAccResourceInstancePoolNameUpdated                 = testAccResourceInstancePoolName + "-updated"
 	testAccResourceInstancePoolInstancePrefix              = "test"
@@ -123,6 +125,9 @@ resource "exoscale_instance_pool" "test" {
   network_ids = [exoscale_network.test.id]
   elastic_ip_ids = [exoscale_ipaddress.test.id]
   user_data = "%s"
+  labels = { test = "%s" }
+  propagate_labels = true
+  allow_reboot_for_resize = true
 
   timeouts {
     delete = "10m"
@@ -138,6 +143,7 @@ resource "exoscale_instance_pool" "test" {
 		testAccResourceInstancePoolSizeUpdated,
 		testAccResourceInstancePoolDiskSizeUpdated,
 		testAccResourceInstancePoolUserDataUpdated,
+		testAccResourceInstancePoolLabelValue,
 	)
 )
 
@@ -180,6 +186,17 @@ func TestAccResourceInstancePool(t *testing.T) {
 						a.Equal(templateID, *instancePool.TemplateID)
 						a.Equal(expectedUserData, *instancePool.UserData)
 
+						client := GetComputeClient(testAccProvider.Meta())
+						ctx := exoapi.WithEndpoint(
+							context.Background(),
+							exoapi.NewReqEndpoint(testEnvironment, testZoneName),
+						)
+						for _, id := range *instancePool.InstanceIDs {
+							instance, err := client.GetInstance(ctx, testZoneName, id)
+							a.NoError(err, "unable to retrieve Compute instance")
+							a.Equal(testAccResourceInstancePoolDiskSizeUpdated, *instance.DiskSize)
+						}
+
 						return nil
 					},
 					checkResourceState(r, checkResourceStateValidateAttributes(testAttrs{
@@ -227,6 +244,7 @@ func TestAccResourceInstancePool(t *testing.T) {
 						a.Len(*instancePool.PrivateNetworkIDs, 1)
 						a.Equal(testAccResourceInstancePoolSizeUpdated, *instancePool.Size)
 						a.Equal(testAccResourceInstancePoolKeyPair, *instancePool.SSHKey)
+						a.Equal(map[string]string{"test": testAccResourceInstancePoolLabelValue}, *instancePool.Labels)
 						a.Equal(templateID, *instancePool.TemplateID)
 						a.Equal(expectedUserData, *instancePool.UserData)
 
@@ -241,6 +259,7 @@ func TestAccResourceInstancePool(t *testing.T) {
 						resInstancePoolAttrInstanceType:            validateString(testAccResourceInstancePoolInstanceTypeUpdated),
 						resInstancePoolAttrIPv6:                    validateString("false"),
 						resInstancePoolAttrKeyPair:                 validateString(testAccResourceInstancePoolKeyPair),
+						resInstancePoolAttrLabels + ".test":        validateString(testAccResourceInstancePoolLabelValue),
 						resInstancePoolAttrName:                    validateString(testAccResourceInstancePoolNameUpdated),
 						resInstancePoolAttrNetworkIDs + ".#":       validateString("1"),
 						resInstancePoolAttrSize:                    validateString(fmt.Sprint(testAccResourceInstancePoolSizeUpdated)),
@@ -250,6 +269,51 @@ func TestAccResourceInstancePool(t *testing.T) {
 					resource.TestCheckNoResourceAttr(r, resInstancePoolAttrSecurityGroupIDs+".#"),
 				),
 			},
+			{
+				// Member labels drift
+				PreConfig: func() {
+					client := GetComputeClient(testAccProvider.Meta())
+					ctx := exoapi.WithEndpoint(
+						context.Background(),
+						exoapi.NewReqEndpoint(testEnvironment, testZoneName),
+					)
+
+					id := (*instancePool.InstanceIDs)[0]
+					labels := map[string]string{}
+					if err := client.UpdateInstance(ctx, testZoneName, &exov2.Instance{ID: &id, Labels: &labels}); err != nil {
+						t.Fatalf("unable to update Compute instance labels: %s", err)
+					}
+				},
+				Config:             testAccResourceInstancePoolConfigUpdate,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// Member labels drift reconciliation
+				Config: testAccResourceInstancePoolConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceInstancePoolExists(r, &instancePool),
+					func(s *terraform.State) error {
+						a := require.New(t)
+
+						client := GetComputeClient(testAccProvider.Meta())
+						ctx := exoapi.WithEndpoint(
+							context.Background(),
+							exoapi.NewReqEndpoint(testEnvironment, testZoneName),
+						)
+						for _, id := range *instancePool.InstanceIDs {
+							instance, err := client.GetInstance(ctx, testZoneName, id)
+							a.NoError(err, "unable to retrieve Compute instance")
+							a.Equal(testAccResourceInstancePoolLabelValue, (*instance.Labels)["test"])
+						}
+
+						return nil
+					},
+					checkResourceState(r, checkResourceStateValidateAttributes(testAttrs{
+						resInstancePoolAttrPropagateLabels: validateString("true"),
+					})),
+				),
+			},
 			{
 				// Import
 				ResourceName: r,
@@ -260,6 +324,10 @@ func TestAccResourceInstancePool(t *testing.T) {
 				}(&instancePool),
 				ImportState:       true,
 				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					resInstancePoolAttrAllowRebootForResize,
+					resInstancePoolAttrPropagateLabels,
+				},
 				ImportStateCheck: func(s []*terraform.InstanceState) error {
 					return checkResourceAttributes(
 						testAttrs{
@@ -271,6 +339,7 @@ func TestAccResourceInstancePool(t *testing.T) {
 							resInstancePoolAttrInstanceType:            validateString(testAccResourceInstancePoolInstanceTypeUpdated),
 							resInstancePoolAttrIPv6:                    validateString("false"),
 							resInstancePoolAttrKeyPair:                 validateString(testAccResourceInstancePoolKeyPair),
+							resInstancePoolAttrLabels + ".test":        validateString(testAccResourceInstancePoolLabelValue),
 							resInstancePoolAttrName:                    validateString(testAccResourceInstancePoolNameUpdated),
 							resInstancePoolAttrNetworkIDs + ".#":       validateString("1"),
 							resInstancePoolAttrSize:                    validateString(fmt.Sprint(testAccResourceInstancePoolSizeUpdated)),
@@ -284,6 +353,55 @@ func TestAccResourceInstancePool(t *testing.T) {
 	})
 }
 
+func TestMergeInstancePoolLabels(t *testing.T) {
+	labels, changed := mergeInstancePoolLabels(
+		map[string]string{"app": "web", "env": "prod", "team": "a"},
+		map[string]string{"env": "prod", "team": "a"},
+		map[string]string{"env": "staging"},
+	)
+	require.True(t, changed)
+	require.Equal(t, map[string]string{"app": "web", "env": "staging"}, labels)
+
+	labels, changed = mergeInstancePoolLabels(
+		map[string]string{"env": "prod", "team": "b"},
+		map[string]string{"team": "a"},
+		map[string]string{"env": "prod"},
+	)
+	require.False(t, changed)
+	require.Equal(t, map[string]string{"env": "prod", "team": "b"}, labels)
+
+	labels, changed = mergeInstancePoolLabels(nil, nil, map[string]string{"env": "prod"})
+	require.True(t, changed)
+	require.Equal(t, map[string]string{"env": "prod"}, labels)
+}
+
+func TestFindLatestTemplate(t *testing.T) {
+	var (
+		id1, id2, id3 = "1", "2", "3"
+		ubuntu        = "Linux Ubuntu 20.04 LTS 64-bit"
+		debian        = "Linux Debian 11 (Bullseye) 64-bit"
+		createdAt1    = time.Now()
+		createdAt2    = createdAt1.Add(time.Hour)
+		createdAt3    = createdAt1.Add(2 * time.Hour)
+		templates     = []*exov2.Template{
+			{ID: &id1, Name: &ubuntu, CreatedAt: &createdAt1},
+			{ID: &id2, Name: &ubuntu, CreatedAt: &createdAt2},
+			{ID: &id3, Name: &debian, CreatedAt: &createdAt3},
+		}
+	)
+
+	actual, err := findLatestTemplate(templates, regexp.MustCompile(`^Linux Ubuntu 20\.04`))
+	require.NoError(t, err)
+	require.Equal(t, id2, *actual.ID)
+
+	actual, err = findLatestTemplate(templates, regexp.MustCompile(`^Linux`))
+	require.NoError(t, err)
+	require.Equal(t, id3, *actual.ID)
+
+	_, err = findLatestTemplate(templates, regexp.MustCompile(`Windows`))
+	require.Error(t, err)
+}
+
 func testAccCheckResourceInstancePoolExists(r string, instancePool *exov2.InstancePool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[r]
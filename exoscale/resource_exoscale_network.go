@@ -52,6 +52,11 @@ func resourceNetwork() *schema.Resource {
 			Optional:     true,
 			ValidateFunc: validation.IsIPAddress,
 		},
+		"network_domain": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
 	}
 
 	addTags(s, "tags")
@@ -108,12 +113,13 @@ func resourceNetworkCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	req := &egoscale.CreateNetwork{
-		Name:        name,
-		DisplayText: displayText,
-		ZoneID:      zone.ID,
-		StartIP:     startIP,
-		EndIP:       endIP,
-		Netmask:     netmask,
+		Name:          name,
+		DisplayText:   displayText,
+		ZoneID:        zone.ID,
+		StartIP:       startIP,
+		EndIP:         endIP,
+		Netmask:       netmask,
+		NetworkDomain: d.Get("network_domain").(string),
 	}
 
 	resp, err := client.RequestWithContext(ctx, req)
@@ -241,14 +247,15 @@ func resourceNetworkUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	// Update name and display_text
+	// Update name, display_text and managed network settings
 	updateNetwork := &egoscale.UpdateNetwork{
-		ID:          id,
-		Name:        d.Get("name").(string),
-		DisplayText: d.Get("display_text").(string),
-		StartIP:     net.ParseIP(d.Get("start_ip").(string)),
-		EndIP:       net.ParseIP(d.Get("end_ip").(string)),
-		Netmask:     net.ParseIP(d.Get("netmask").(string)),
+		ID:            id,
+		Name:          d.Get("name").(string),
+		DisplayText:   d.Get("display_text").(string),
+		StartIP:       net.ParseIP(d.Get("start_ip").(string)),
+		EndIP:         net.ParseIP(d.Get("end_ip").(string)),
+		Netmask:       net.ParseIP(d.Get("netmask").(string)),
+		NetworkDomain: d.Get("network_domain").(string),
 	}
 
 	// Update tags
@@ -325,6 +332,10 @@ func resourceNetworkApply(d *schema.ResourceData, network *egoscale.Network) err
 		d.Set("netmask", "")  // nolint: errcheck
 	}
 
+	if err := d.Set("network_domain", network.NetworkDomain); err != nil {
+		return err
+	}
+
 	// tags
 	tags := make(map[string]interface{})
 	for _, tag := range network.Tags {
@@ -22,6 +22,8 @@ var (
 	testAccResourceNetworkEndIPUpdated   = "10.0.0.100"
 	testAccResourceNetworkNetmask        = "255.255.0.0"
 	testAccResourceNetworkNetmaskUpdated = "255.0.0.0"
+	testAccResourceNetworkDomain         = "example.net"
+	testAccResourceNetworkDomainUpdated  = "example.org"
 
 	testAccResourceNetworkConfigCreate = fmt.Sprintf(`
 resource "exoscale_network" "net" {
@@ -32,6 +34,7 @@ resource "exoscale_network" "net" {
   start_ip = "%s"
   end_ip = "%s"
   netmask = "%s"
+  network_domain = "%s"
 
   tags = {
     managedby = "terraform"
@@ -44,6 +47,7 @@ resource "exoscale_network" "net" {
 		testAccResourceNetworkStartIP,
 		testAccResourceNetworkEndIP,
 		testAccResourceNetworkNetmask,
+		testAccResourceNetworkDomain,
 	)
 
 	testAccResourceNetworkConfigUpdate = fmt.Sprintf(`
@@ -54,6 +58,7 @@ resource "exoscale_network" "net" {
   start_ip = "%s"
   end_ip = "%s"
   netmask = "%s"
+  network_domain = "%s"
 }
 `,
 		testAccResourceNetworkZoneName,
@@ -61,6 +66,7 @@ resource "exoscale_network" "net" {
 		testAccResourceNetworkStartIPUpdated,
 		testAccResourceNetworkEndIPUpdated,
 		testAccResourceNetworkNetmaskUpdated,
+		testAccResourceNetworkDomainUpdated,
 	)
 )
 
@@ -82,6 +88,7 @@ func TestAccResourceNetwork(t *testing.T) {
 						"start_ip":       validateString(testAccResourceNetworkStartIP),
 						"end_ip":         validateString(testAccResourceNetworkEndIP),
 						"netmask":        validateString(testAccResourceNetworkNetmask),
+						"network_domain": validateString(testAccResourceNetworkDomain),
 						"tags.managedby": validateString("terraform"),
 					}),
 				),
@@ -92,11 +99,12 @@ func TestAccResourceNetwork(t *testing.T) {
 					testAccCheckResourceNetworkExists("exoscale_network.net", network),
 					testAccCheckResourceNetwork(network),
 					testAccCheckResourceNetworkAttributes(testAttrs{
-						"name":         validateString(testAccResourceNetworkNameUpdated),
-						"display_text": validateString(testAccResourceNetworkDisplayText),
-						"start_ip":     validateString(testAccResourceNetworkStartIPUpdated),
-						"end_ip":       validateString(testAccResourceNetworkEndIPUpdated),
-						"netmask":      validateString(testAccResourceNetworkNetmaskUpdated),
+						"name":           validateString(testAccResourceNetworkNameUpdated),
+						"display_text":   validateString(testAccResourceNetworkDisplayText),
+						"start_ip":       validateString(testAccResourceNetworkStartIPUpdated),
+						"end_ip":         validateString(testAccResourceNetworkEndIPUpdated),
+						"netmask":        validateString(testAccResourceNetworkNetmaskUpdated),
+						"network_domain": validateString(testAccResourceNetworkDomainUpdated),
 					}),
 				),
 			},
@@ -107,11 +115,12 @@ func TestAccResourceNetwork(t *testing.T) {
 				ImportStateCheck: func(s []*terraform.InstanceState) error {
 					return checkResourceAttributes(
 						testAttrs{
-							"name":         validateString(testAccResourceNetworkNameUpdated),
-							"display_text": validateString(testAccResourceNetworkDisplayText),
-							"start_ip":     validateString(testAccResourceNetworkStartIPUpdated),
-							"end_ip":       validateString(testAccResourceNetworkEndIPUpdated),
-							"netmask":      validateString(testAccResourceNetworkNetmaskUpdated),
+							"name":           validateString(testAccResourceNetworkNameUpdated),
+							"display_text":   validateString(testAccResourceNetworkDisplayText),
+							"start_ip":       validateString(testAccResourceNetworkStartIPUpdated),
+							"end_ip":         validateString(testAccResourceNetworkEndIPUpdated),
+							"netmask":        validateString(testAccResourceNetworkNetmaskUpdated),
+							"network_domain": validateString(testAccResourceNetworkDomainUpdated),
 						},
 						s[0].Attributes)
 				},
@@ -16,6 +16,7 @@ const (
 	resNLBAttrCreatedAt   = "created_at"
 	resNLBAttrDescription = "description"
 	resNLBAttrIPAddress   = "ip_address"
+	resNLBAttrLabels      = "labels"
 	resNLBAttrName        = "name"
 	resNLBAttrServices    = "services"
 	resNLBAttrState       = "state"
@@ -40,6 +41,11 @@ func resourceNLB() *schema.Resource {
 			Type:     schema.TypeString,
 			Computed: true,
 		},
+		resNLBAttrLabels: {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
 		resNLBAttrName: {
 			Type:     schema.TypeString,
 			Required: true,
@@ -103,6 +109,14 @@ func resourceNLBCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 		nlb.Description = &s
 	}
 
+	if l, ok := d.GetOk(resNLBAttrLabels); ok {
+		labels := make(map[string]string)
+		for k, v := range l.(map[string]interface{}) {
+			labels[k] = v.(string)
+		}
+		nlb.Labels = &labels
+	}
+
 	nlb, err := client.CreateNetworkLoadBalancer(ctx, zone, nlb)
 	if err != nil {
 		return diag.FromErr(err)
@@ -171,6 +185,15 @@ func resourceNLBUpdate(ctx context.Context, d *schema.ResourceData, meta interfa
 		updated = true
 	}
 
+	if d.HasChange(resNLBAttrLabels) {
+		labels := make(map[string]string)
+		for k, v := range d.Get(resNLBAttrLabels).(map[string]interface{}) {
+			labels[k] = v.(string)
+		}
+		nlb.Labels = &labels
+		updated = true
+	}
+
 	if updated {
 		if err = client.UpdateNetworkLoadBalancer(ctx, zone, nlb); err != nil {
 			return diag.FromErr(err)
@@ -216,6 +239,14 @@ func resourceNLBApply(_ context.Context, d *schema.ResourceData, nlb *exov2.Netw
 		return diag.FromErr(err)
 	}
 
+	if nlb.Labels != nil {
+		if err := d.Set(resNLBAttrLabels, *nlb.Labels); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if err := d.Set(resNLBAttrLabels, map[string]string{}); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err := d.Set(resNLBAttrName, *nlb.Name); err != nil {
 		return diag.FromErr(err)
 	}
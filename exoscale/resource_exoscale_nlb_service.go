@@ -8,10 +8,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/exoscale/egoscale"
 	exov2 "github.com/exoscale/egoscale/v2"
 	exoapi "github.com/exoscale/egoscale/v2/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
@@ -22,6 +25,8 @@ const (
 	defaultNLBServiceProtocol            = "tcp"
 	defaulNLBServiceStrategy             = "round-robin"
 
+	nlbServiceHealthcheckStatusSuccess = "success"
+
 	resNLBServiceAttrDescription         = "description"
 	resNLBServiceAttrHealthcheck         = "healthcheck"
 	resNLBServiceAttrHealthcheckInterval = "interval"
@@ -39,6 +44,8 @@ const (
 	resNLBServiceAttrStrategy            = "strategy"
 	resNLBServiceAttrState               = "state"
 	resNLBServiceAttrTargetPort          = "target_port"
+	resNLBServiceAttrWaitForHealthy      = "wait_for_healthy"
+	resNLBServiceAttrWaitForHealthyCount = "wait_for_healthy_count"
 	resNLBServiceAttrZone                = "zone"
 )
 
@@ -58,28 +65,33 @@ func resourceNLBService() *schema.Resource {
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					resNLBServiceAttrHealthcheckInterval: {
-						Type:     schema.TypeInt,
-						Optional: true,
-						Default:  defaultNLBServiceHealthcheckInterval,
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      defaultNLBServiceHealthcheckInterval,
+						ValidateFunc: validation.IntAtLeast(1),
 					},
 					resNLBServiceAttrHealthcheckMode: {
-						Type:     schema.TypeString,
-						Optional: true,
-						Default:  defaultNLBServiceHealthcheckMode,
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      defaultNLBServiceHealthcheckMode,
+						ValidateFunc: validation.StringInSlice([]string{"tcp", "http", "https"}, false),
 					},
 					resNLBServiceAttrHealthcheckPort: {
-						Type:     schema.TypeInt,
-						Required: true,
+						Type:         schema.TypeInt,
+						Required:     true,
+						ValidateFunc: validation.IsPortNumber,
 					},
 					resNLBServiceAttrHealthcheckRetries: {
-						Type:     schema.TypeInt,
-						Optional: true,
-						Default:  defaultNLBServiceHealthcheckRetries,
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      defaultNLBServiceHealthcheckRetries,
+						ValidateFunc: validation.IntAtLeast(1),
 					},
 					resNLBServiceAttrHealthcheckTimeout: {
-						Type:     schema.TypeInt,
-						Optional: true,
-						Default:  defaultNLBServiceHealthcheckTimeout,
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      defaultNLBServiceHealthcheckTimeout,
+						ValidateFunc: validation.IntAtLeast(1),
 					},
 					resNLBServiceAttrHealthcheckTLSSNI: {
 						Type:     schema.TypeString,
@@ -95,6 +107,7 @@ func resourceNLBService() *schema.Resource {
 		resNLBServiceAttrInstancePoolID: {
 			Type:     schema.TypeString,
 			Required: true,
+			ForceNew: true,
 		},
 		resNLBServiceAttrName: {
 			Type:     schema.TypeString,
@@ -106,26 +119,43 @@ func resourceNLBService() *schema.Resource {
 			ForceNew: true,
 		},
 		resNLBServiceAttrPort: {
-			Type:     schema.TypeInt,
-			Required: true,
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IsPortNumber,
 		},
 		resNLBServiceAttrProtocol: {
-			Type:     schema.TypeString,
-			Optional: true,
-			Default:  defaultNLBServiceProtocol,
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      defaultNLBServiceProtocol,
+			ValidateFunc: validation.StringInSlice([]string{"tcp", "udp"}, false),
 		},
 		resNLBServiceAttrState: {
 			Type:     schema.TypeString,
 			Computed: true,
 		},
 		resNLBServiceAttrStrategy: {
-			Type:     schema.TypeString,
-			Optional: true,
-			Default:  defaulNLBServiceStrategy,
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      defaulNLBServiceStrategy,
+			ValidateFunc: validation.StringInSlice([]string{"round-robin", "source-hash"}, false),
 		},
 		resNLBServiceAttrTargetPort: {
-			Type:     schema.TypeInt,
-			Required: true,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IsPortNumber,
+			Description:  "Port to forward network traffic to on target instances (default: same as port)",
+		},
+		resNLBServiceAttrWaitForHealthy: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Wait for backends to pass the service healthcheck on create and update",
+		},
+		resNLBServiceAttrWaitForHealthyCount: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      1,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Minimum number of healthy backends to wait for if wait_for_healthy is set",
 		},
 		resNLBServiceAttrZone: {
 			Type:     schema.TypeString,
@@ -137,6 +167,8 @@ func resourceNLBService() *schema.Resource {
 	return &schema.Resource{
 		Schema: s,
 
+		CustomizeDiff: resourceNLBServiceCustomizeDiff,
+
 		CreateContext: resourceNLBServiceCreate,
 		ReadContext:   resourceNLBServiceRead,
 		UpdateContext: resourceNLBServiceUpdate,
@@ -173,6 +205,76 @@ func resourceNLBService() *schema.Resource {
 	}
 }
 
+// resourceNLBServiceCustomizeDiff rejects at plan time healthcheck settings
+// that are not compatible with the healthcheck mode or the service protocol,
+// instead of letting the API fail during apply (or the backends never pass
+// the healthcheck).
+func resourceNLBServiceCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	set, ok := d.Get(resNLBServiceAttrHealthcheck).(*schema.Set)
+	if !ok || set.Len() == 0 {
+		return nil
+	}
+	healthcheck := set.List()[0].(map[string]interface{})
+
+	if err := validateNLBServiceHealthcheck(healthcheck); err != nil {
+		return err
+	}
+
+	targetPort := d.Get(resNLBServiceAttrTargetPort).(int)
+	if targetPort == 0 {
+		targetPort = d.Get(resNLBServiceAttrPort).(int)
+	}
+
+	return validateNLBServiceHealthcheckProtocol(d.Get(resNLBServiceAttrProtocol).(string), targetPort, healthcheck)
+}
+
+// validateNLBServiceHealthcheck checks that the URI and TLS SNI settings of an
+// NLB service healthcheck are only set for the modes supporting them.
+func validateNLBServiceHealthcheck(healthcheck map[string]interface{}) error {
+	mode, _ := healthcheck[resNLBServiceAttrHealthcheckMode].(string)
+
+	if v, _ := healthcheck[resNLBServiceAttrHealthcheckURI].(string); v != "" && !strings.HasPrefix(mode, "http") {
+		return fmt.Errorf("healthcheck %q can only be set with %q or %q mode", resNLBServiceAttrHealthcheckURI, "http", "https")
+	}
+
+	if v, _ := healthcheck[resNLBServiceAttrHealthcheckTLSSNI].(string); v != "" && mode != "https" {
+		return fmt.Errorf("healthcheck %q can only be set with %q mode", resNLBServiceAttrHealthcheckTLSSNI, "https")
+	}
+
+	return nil
+}
+
+// validateNLBServiceHealthcheckProtocol checks that the healthcheck of an NLB
+// service can probe its backends: all the healthcheck modes are TCP-based, so
+// a "udp" service must be health-checked on a port different from its target
+// port. A port that is not known yet (0) is not checked.
+func validateNLBServiceHealthcheckProtocol(protocol string, targetPort int, healthcheck map[string]interface{}) error {
+	if protocol != "udp" {
+		return nil
+	}
+
+	port, _ := healthcheck[resNLBServiceAttrHealthcheckPort].(int)
+	if port != 0 && port == targetPort {
+		mode, _ := healthcheck[resNLBServiceAttrHealthcheckMode].(string)
+		return fmt.Errorf(
+			"healthcheck %q mode cannot probe the %q target port %d of the service, set the healthcheck %q to a TCP port of the backends",
+			mode, protocol, targetPort, resNLBServiceAttrHealthcheckPort,
+		)
+	}
+
+	return nil
+}
+
+// nlbServiceTargetPort returns the target port of the NLB service, which
+// defaults to the service port if unset.
+func nlbServiceTargetPort(d *schema.ResourceData) uint16 {
+	if v := d.Get(resNLBServiceAttrTargetPort).(int); v != 0 {
+		return uint16(v)
+	}
+
+	return uint16(d.Get(resNLBServiceAttrPort).(int))
+}
+
 func resourceNLBServiceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[DEBUG] %s: beginning create", resourceNLBServiceIDString(d))
 
@@ -243,7 +345,7 @@ func resourceNLBServiceCreate(ctx context.Context, d *schema.ResourceData, meta
 	nlbServiceStrategy := d.Get(resNLBServiceAttrStrategy).(string)
 	nlbService.Strategy = &nlbServiceStrategy
 
-	nlbServiceTargetPort := uint16(d.Get(resNLBServiceAttrTargetPort).(int))
+	nlbServiceTargetPort := nlbServiceTargetPort(d)
 	nlbService.TargetPort = &nlbServiceTargetPort
 
 	nlbService, err = nlb.AddService(ctx, nlbService)
@@ -253,6 +355,12 @@ func resourceNLBServiceCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	d.SetId(*nlbService.ID)
 
+	if d.Get(resNLBServiceAttrWaitForHealthy).(bool) {
+		if err := waitForNLBServiceHealthy(ctx, d, client, zone, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.Errorf("unable to wait for Network Load Balancer Service to be healthy: %s", err)
+		}
+	}
+
 	log.Printf("[DEBUG] %s: create finished successfully", resourceNLBServiceIDString(d))
 
 	return resourceNLBServiceRead(ctx, d, meta)
@@ -356,8 +464,10 @@ func resourceNLBServiceUpdate(ctx context.Context, d *schema.ResourceData, meta
 		updated = true
 	}
 
-	if d.HasChange(resNLBServiceAttrTargetPort) {
-		v := uint16(d.Get(resNLBServiceAttrTargetPort).(int))
+	// When unset, the target port follows the service port.
+	if d.HasChange(resNLBServiceAttrTargetPort) ||
+		(d.HasChange(resNLBServiceAttrPort) && d.Get(resNLBServiceAttrTargetPort).(int) == 0) {
+		v := nlbServiceTargetPort(d)
 		nlbService.TargetPort = &v
 		updated = true
 	}
@@ -404,6 +514,13 @@ func resourceNLBServiceUpdate(ctx context.Context, d *schema.ResourceData, meta
 		if err = nlb.UpdateService(ctx, nlbService); err != nil {
 			return diag.FromErr(err)
 		}
+
+		if d.Get(resNLBServiceAttrWaitForHealthy).(bool) &&
+			d.HasChanges(resNLBServiceAttrTargetPort, resNLBServiceAttrHealthcheck) {
+			if err := waitForNLBServiceHealthy(ctx, d, client, zone, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return diag.Errorf("unable to wait for Network Load Balancer Service to be healthy: %s", err)
+			}
+		}
 	}
 
 	log.Printf("[DEBUG] %s: update finished successfully", resourceNLBServiceIDString(d))
@@ -482,9 +599,75 @@ func resourceNLBServiceApply(_ context.Context, d *schema.ResourceData, nlbServi
 		return diag.FromErr(err)
 	}
 
-	if err := d.Set(resNLBServiceAttrTargetPort, *nlbService.TargetPort); err != nil {
-		return diag.FromErr(err)
+	// An unset target port is kept as is as long as it matches the service
+	// port, in order not to report a diff for the default value.
+	if d.Get(resNLBServiceAttrTargetPort).(int) != 0 || *nlbService.TargetPort != *nlbService.Port {
+		if err := d.Set(resNLBServiceAttrTargetPort, *nlbService.TargetPort); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return nil
 }
+
+// waitForNLBServiceHealthy waits until at least wait_for_healthy_count
+// backends of the NLB service pass its healthcheck.
+func waitForNLBServiceHealthy(
+	ctx context.Context,
+	d *schema.ResourceData,
+	client *egoscale.Client,
+	zone string,
+	timeout time.Duration,
+) error {
+	expected := d.Get(resNLBServiceAttrWaitForHealthyCount).(int)
+	progress := newWaitProgress(resourceNLBServiceIDString(d), "waiting for healthy backends", timeout)
+
+	_, err := (&resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"healthy"},
+		Refresh: func() (interface{}, string, error) {
+			nlb, err := client.GetNetworkLoadBalancer(ctx, zone, d.Get(resNLBServiceAttrNLBID).(string))
+			if err != nil {
+				return nil, "", err
+			}
+
+			for _, nlbService := range nlb.Services {
+				if *nlbService.ID != d.Id() {
+					continue
+				}
+
+				healthy := countNLBServiceHealthyBackends(nlbService.HealthcheckStatus)
+				log.Printf("[DEBUG] %s: %d/%d healthy backend(s)",
+					resourceNLBServiceIDString(d),
+					healthy,
+					expected)
+				progress.report(fmt.Sprintf("%d/%d healthy backend(s)", healthy, expected))
+
+				if healthy >= expected {
+					return nlbService, "healthy", nil
+				}
+				return nlbService, "pending", nil
+			}
+
+			return nil, "", fmt.Errorf("Network Load Balancer Service %q not found", d.Id())
+		},
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+		Timeout:    timeout,
+	}).WaitForStateContext(ctx)
+
+	return err
+}
+
+// countNLBServiceHealthyBackends returns the number of NLB service backends
+// passing the service healthcheck.
+func countNLBServiceHealthyBackends(statuses []*exov2.NetworkLoadBalancerServerStatus) int {
+	var healthy int
+	for _, status := range statuses {
+		if defaultString(status.Status, "") == nlbServiceHealthcheckStatusSuccess {
+			healthy++
+		}
+	}
+
+	return healthy
+}
@@ -300,6 +300,10 @@ func TestAccResourceNLBService(t *testing.T) {
 				}(&nlb, &nlbService),
 				ImportState:       true,
 				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					resNLBServiceAttrWaitForHealthy,
+					resNLBServiceAttrWaitForHealthyCount,
+				},
 				ImportStateCheck: func(s []*terraform.InstanceState) error {
 					return checkResourceAttributes(
 						testAttrs{
@@ -327,6 +331,63 @@ func TestAccResourceNLBService(t *testing.T) {
 	})
 }
 
+func TestCountNLBServiceHealthyBackends(t *testing.T) {
+	var (
+		success = nlbServiceHealthcheckStatusSuccess
+		failure = "failure"
+	)
+
+	require.Equal(t, 0, countNLBServiceHealthyBackends(nil))
+	require.Equal(t, 2, countNLBServiceHealthyBackends([]*exov2.NetworkLoadBalancerServerStatus{
+		{Status: &success},
+		{Status: &failure},
+		{Status: &success},
+		{},
+	}))
+}
+
+func TestValidateNLBServiceHealthcheck(t *testing.T) {
+	for _, tt := range []struct {
+		healthcheck map[string]interface{}
+		valid       bool
+	}{
+		{healthcheck: map[string]interface{}{"mode": "tcp"}, valid: true},
+		{healthcheck: map[string]interface{}{"mode": "http", "uri": "/healthz"}, valid: true},
+		{healthcheck: map[string]interface{}{"mode": "https", "uri": "/healthz", "tls_sni": "example.net"}, valid: true},
+		{healthcheck: map[string]interface{}{"mode": "tcp", "uri": "/healthz"}, valid: false},
+		{healthcheck: map[string]interface{}{"mode": "http", "tls_sni": "example.net"}, valid: false},
+	} {
+		err := validateNLBServiceHealthcheck(tt.healthcheck)
+		if tt.valid {
+			require.NoError(t, err, tt.healthcheck)
+		} else {
+			require.Error(t, err, tt.healthcheck)
+		}
+	}
+}
+
+func TestValidateNLBServiceHealthcheckProtocol(t *testing.T) {
+	for _, tt := range []struct {
+		protocol    string
+		targetPort  int
+		healthcheck map[string]interface{}
+		valid       bool
+	}{
+		{protocol: "tcp", targetPort: 80, healthcheck: map[string]interface{}{"mode": "tcp", "port": 80}, valid: true},
+		{protocol: "udp", targetPort: 53, healthcheck: map[string]interface{}{"mode": "tcp", "port": 8080}, valid: true},
+		{protocol: "udp", targetPort: 53, healthcheck: map[string]interface{}{"mode": "http", "port": 0}, valid: true},
+		{protocol: "udp", targetPort: 53, healthcheck: map[string]interface{}{"mode": "tcp", "port": 53}, valid: false},
+		{protocol: "udp", targetPort: 53, healthcheck: map[string]interface{}{"mode": "http", "port": 53}, valid: false},
+	} {
+		err := validateNLBServiceHealthcheckProtocol(tt.protocol, tt.targetPort, tt.healthcheck)
+		if tt.valid {
+			require.NoError(t, err, tt.healthcheck)
+		} else {
+			require.Error(t, err, tt.healthcheck)
+		}
+	}
+}
+
 func testAccCheckResourceNLBServiceExists(r string, nlbService *exov2.NetworkLoadBalancerService) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[r]
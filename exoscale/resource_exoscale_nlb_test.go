@@ -22,6 +22,7 @@ var (
 	testAccResourceNLBName        = acctest.RandomWithPrefix(testPrefix)
 	testAccResourceNLBNameUpdated = testAccResourceNLBName + "-updated"
 	testAccResourceNLBDescription = acctest.RandString(10)
+	testAccResourceNLBLabelValue  = acctest.RandString(10)
 
 	testAccResourceNLBConfigCreate = fmt.Sprintf(`
 locals {
@@ -45,6 +46,9 @@ resource "exoscale_nlb" "test" {
   name = "%s"
   description = "%s"
   zone = local.zone
+  labels = {
+    test = "%s"
+  }
 
   timeouts {
     delete = "10m"
@@ -80,6 +84,7 @@ resource "exoscale_nlb_service" "test" {
 		testAccResourceNLBInstancePoolTemplateID,
 		testAccResourceNLBName,
 		testAccResourceNLBDescription,
+		testAccResourceNLBLabelValue,
 		testAccResourceNLBName,
 	)
 
@@ -163,18 +168,20 @@ func TestAccResourceNLB(t *testing.T) {
 						a := require.New(t)
 
 						a.Equal(testAccResourceNLBDescription, *nlb.Description)
+						a.Equal(map[string]string{"test": testAccResourceNLBLabelValue}, *nlb.Labels)
 						a.Equal(testAccResourceNLBName, *nlb.Name)
 						a.Len(nlb.Services, 1)
 
 						return nil
 					},
 					checkResourceState(r, checkResourceStateValidateAttributes(testAttrs{
-						resNLBAttrCreatedAt:   validation.ToDiagFunc(validation.NoZeroValues),
-						resNLBAttrDescription: validateString(testAccResourceNLBDescription),
-						resNLBAttrIPAddress:   validation.ToDiagFunc(validation.IsIPv4Address),
-						resNLBAttrName:        validateString(testAccResourceNLBName),
-						resNLBAttrState:       validation.ToDiagFunc(validation.NoZeroValues),
-						resNLBAttrZone:        validateString(testZoneName),
+						resNLBAttrCreatedAt:        validation.ToDiagFunc(validation.NoZeroValues),
+						resNLBAttrDescription:      validateString(testAccResourceNLBDescription),
+						resNLBAttrIPAddress:        validation.ToDiagFunc(validation.IsIPv4Address),
+						resNLBAttrLabels + ".test": validateString(testAccResourceNLBLabelValue),
+						resNLBAttrName:             validateString(testAccResourceNLBName),
+						resNLBAttrState:            validation.ToDiagFunc(validation.NoZeroValues),
+						resNLBAttrZone:             validateString(testZoneName),
 
 						// Note: can't test the resNLBAttrServices attribute yet, as the
 						// exoscale_nlb_service resource is created after the exoscale_nlb
@@ -201,6 +208,7 @@ func TestAccResourceNLB(t *testing.T) {
 						resNLBAttrCreatedAt:       validation.ToDiagFunc(validation.NoZeroValues),
 						resNLBAttrDescription:     validation.ToDiagFunc(validation.StringIsEmpty),
 						resNLBAttrIPAddress:       validation.ToDiagFunc(validation.IsIPv4Address),
+						resNLBAttrLabels + ".%":   validateString("0"),
 						resNLBAttrName:            validateString(testAccResourceNLBNameUpdated),
 						resNLBAttrServices + ".#": validateString("1"),
 						resNLBAttrState:           validation.ToDiagFunc(validation.NoZeroValues),
@@ -0,0 +1,387 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/exoscale/egoscale"
+	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	resResourceLabelsAttrLabels       = "labels"
+	resResourceLabelsAttrResourceID   = "resource_id"
+	resResourceLabelsAttrResourceType = "resource_type"
+	resResourceLabelsAttrZone         = "zone"
+
+	resourceLabelsTypeComputeInstance = "compute_instance"
+	resourceLabelsTypeInstancePool    = "instance_pool"
+	resourceLabelsTypeNLB             = "nlb"
+	resourceLabelsTypeSKSCluster      = "sks_cluster"
+)
+
+var resourceLabelsTypes = []string{
+	resourceLabelsTypeComputeInstance,
+	resourceLabelsTypeInstancePool,
+	resourceLabelsTypeNLB,
+	resourceLabelsTypeSKSCluster,
+}
+
+func resourceResourceLabelsIDString(d resourceIDStringer) string {
+	return resourceIDString(d, "exoscale_resource_labels")
+}
+
+func resourceResourceLabels() *schema.Resource {
+	s := map[string]*schema.Schema{
+		resResourceLabelsAttrLabels: {
+			Type:        schema.TypeMap,
+			Required:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Labels to manage on the resource (other labels are left untouched)",
+		},
+		resResourceLabelsAttrResourceID: {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		resResourceLabelsAttrResourceType: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice(resourceLabelsTypes, false),
+		},
+		resResourceLabelsAttrZone: {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+	}
+
+	return &schema.Resource{
+		Schema: s,
+
+		CreateContext: resourceResourceLabelsCreate,
+		ReadContext:   resourceResourceLabelsRead,
+		UpdateContext: resourceResourceLabelsUpdate,
+		DeleteContext: resourceResourceLabelsDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceResourceLabelsImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+	}
+}
+
+// resourceResourceLabelsImport imports the labels of a resource identified by
+// "<TYPE>/<RESOURCE-ID>@<ZONE>". As there is no configuration to tell which
+// labels are managed at import time, all the current labels of the resource
+// are imported.
+func resourceResourceLabelsImport(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{},
+) ([]*schema.ResourceData, error) {
+	zonedRes, err := zonedStateContextFunc(ctx, d, meta)
+	if err != nil {
+		return nil, err
+	}
+	d = zonedRes[0]
+
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || !in(resourceLabelsTypes, parts[0]) {
+		return nil, fmt.Errorf(
+			`invalid ID %q, expected format "<TYPE>/<RESOURCE-ID>@<ZONE>" (TYPE: %s)`,
+			d.Id(),
+			strings.Join(resourceLabelsTypes, ", "),
+		)
+	}
+
+	if err := d.Set(resResourceLabelsAttrResourceType, parts[0]); err != nil {
+		return nil, err
+	}
+
+	if err := d.Set(resResourceLabelsAttrResourceID, parts[1]); err != nil {
+		return nil, err
+	}
+
+	zone := d.Get(resResourceLabelsAttrZone).(string)
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+
+	labels, err := getResourceLabels(ctx, GetComputeClient(meta), zone, parts[0], parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.Set(resResourceLabelsAttrLabels, labels); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceResourceLabelsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning create", resourceResourceLabelsIDString(d))
+
+	zone := d.Get(resResourceLabelsAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	var (
+		resourceType = d.Get(resResourceLabelsAttrResourceType).(string)
+		resourceID   = d.Get(resResourceLabelsAttrResourceID).(string)
+	)
+
+	if err := updateResourceLabels(
+		ctx,
+		client,
+		zone,
+		resourceType,
+		resourceID,
+		nil,
+		resourceLabelsFromState(d.Get(resResourceLabelsAttrLabels)),
+	); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", resourceType, resourceID))
+
+	log.Printf("[DEBUG] %s: create finished successfully", resourceResourceLabelsIDString(d))
+
+	return resourceResourceLabelsRead(ctx, d, meta)
+}
+
+func resourceResourceLabelsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning read", resourceResourceLabelsIDString(d))
+
+	zone := d.Get(resResourceLabelsAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	labels, err := getResourceLabels(
+		ctx,
+		client,
+		zone,
+		d.Get(resResourceLabelsAttrResourceType).(string),
+		d.Get(resResourceLabelsAttrResourceID).(string),
+	)
+	if err != nil {
+		if errors.Is(err, exoapi.ErrNotFound) {
+			// The labeled resource doesn't exist anymore, neither do its labels.
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// Only the labels managed by this resource are reported, so that labels
+	// set by other means don't show up as drift.
+	managed := make(map[string]string)
+	for k := range resourceLabelsFromState(d.Get(resResourceLabelsAttrLabels)) {
+		if v, ok := labels[k]; ok {
+			managed[k] = v
+		}
+	}
+
+	if err := d.Set(resResourceLabelsAttrLabels, managed); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: read finished successfully", resourceResourceLabelsIDString(d))
+
+	return nil
+}
+
+func resourceResourceLabelsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning update", resourceResourceLabelsIDString(d))
+
+	zone := d.Get(resResourceLabelsAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutUpdate))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	if d.HasChange(resResourceLabelsAttrLabels) {
+		o, n := d.GetChange(resResourceLabelsAttrLabels)
+		if err := updateResourceLabels(
+			ctx,
+			client,
+			zone,
+			d.Get(resResourceLabelsAttrResourceType).(string),
+			d.Get(resResourceLabelsAttrResourceID).(string),
+			resourceLabelsFromState(o),
+			resourceLabelsFromState(n),
+		); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Printf("[DEBUG] %s: update finished successfully", resourceResourceLabelsIDString(d))
+
+	return resourceResourceLabelsRead(ctx, d, meta)
+}
+
+func resourceResourceLabelsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning delete", resourceResourceLabelsIDString(d))
+
+	zone := d.Get(resResourceLabelsAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutDelete))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	err := updateResourceLabels(
+		ctx,
+		client,
+		zone,
+		d.Get(resResourceLabelsAttrResourceType).(string),
+		d.Get(resResourceLabelsAttrResourceID).(string),
+		resourceLabelsFromState(d.Get(resResourceLabelsAttrLabels)),
+		nil,
+	)
+	if err != nil && !errors.Is(err, exoapi.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: delete finished successfully", resourceResourceLabelsIDString(d))
+
+	return nil
+}
+
+// resourceLabelsFromState converts a labels map attribute value to a map of
+// strings.
+func resourceLabelsFromState(v interface{}) map[string]string {
+	labels := make(map[string]string)
+	for k, v := range v.(map[string]interface{}) {
+		labels[k] = v.(string)
+	}
+
+	return labels
+}
+
+// mergeResourceLabels returns the labels to set on a resource currently
+// labeled with current, for the managed labels to change from old to new:
+// the labels no longer managed are removed, the managed labels are set and
+// the other labels are left untouched.
+func mergeResourceLabels(current, old, new map[string]string) map[string]string {
+	labels := make(map[string]string, len(current))
+	for k, v := range current {
+		if _, ok := old[k]; ok {
+			continue
+		}
+		labels[k] = v
+	}
+
+	for k, v := range new {
+		labels[k] = v
+	}
+
+	return labels
+}
+
+// updateResourceLabels changes the labels managed on the resource of the
+// specified type from old to new, leaving the other labels untouched.
+func updateResourceLabels(
+	ctx context.Context,
+	client *egoscale.Client,
+	zone, resourceType, resourceID string,
+	old, new map[string]string,
+) error {
+	current, err := getResourceLabels(ctx, client, zone, resourceType, resourceID)
+	if err != nil {
+		return err
+	}
+
+	labels := mergeResourceLabels(current, old, new)
+
+	// Only the resource ID and labels are set, so that no other property
+	// of the resource is updated.
+	switch resourceType {
+	case resourceLabelsTypeComputeInstance:
+		return client.UpdateInstance(ctx, zone, &exov2.Instance{ID: &resourceID, Labels: &labels})
+
+	case resourceLabelsTypeInstancePool:
+		return client.UpdateInstancePool(ctx, zone, &exov2.InstancePool{ID: &resourceID, Labels: &labels})
+
+	case resourceLabelsTypeNLB:
+		return client.UpdateNetworkLoadBalancer(ctx, zone, &exov2.NetworkLoadBalancer{ID: &resourceID, Labels: &labels})
+
+	case resourceLabelsTypeSKSCluster:
+		return client.UpdateSKSCluster(ctx, zone, &exov2.SKSCluster{ID: &resourceID, Labels: &labels})
+	}
+
+	return fmt.Errorf("unsupported resource type %q", resourceType)
+}
+
+// getResourceLabels returns the current labels of the resource of the
+// specified type.
+func getResourceLabels(
+	ctx context.Context,
+	client *egoscale.Client,
+	zone, resourceType, resourceID string,
+) (map[string]string, error) {
+	var labels *map[string]string
+
+	switch resourceType {
+	case resourceLabelsTypeComputeInstance:
+		instance, err := client.GetInstance(ctx, zone, resourceID)
+		if err != nil {
+			return nil, err
+		}
+		labels = instance.Labels
+
+	case resourceLabelsTypeInstancePool:
+		instancePool, err := client.GetInstancePool(ctx, zone, resourceID)
+		if err != nil {
+			return nil, err
+		}
+		labels = instancePool.Labels
+
+	case resourceLabelsTypeNLB:
+		nlb, err := client.GetNetworkLoadBalancer(ctx, zone, resourceID)
+		if err != nil {
+			return nil, err
+		}
+		labels = nlb.Labels
+
+	case resourceLabelsTypeSKSCluster:
+		cluster, err := client.GetSKSCluster(ctx, zone, resourceID)
+		if err != nil {
+			return nil, err
+		}
+		labels = cluster.Labels
+
+	default:
+		return nil, fmt.Errorf("unsupported resource type %q", resourceType)
+	}
+
+	if labels == nil {
+		return make(map[string]string), nil
+	}
+
+	return *labels, nil
+}
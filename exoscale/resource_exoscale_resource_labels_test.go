@@ -0,0 +1,182 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAccResourceResourceLabelsInstanceName = acctest.RandomWithPrefix(testPrefix)
+
+	testAccResourceResourceLabelsConfig = `
+locals {
+  zone = "%s"
+}
+
+resource "exoscale_compute_instance" "test" {
+  zone = local.zone
+  name = "%s"
+  type = "standard.tiny"
+  template_id = "%s"
+  disk_size = 10
+  labels = {
+    owner = "terraform"
+  }
+
+  lifecycle {
+    ignore_changes = [labels]
+  }
+
+  timeouts {
+    delete = "10m"
+  }
+}
+
+resource "exoscale_resource_labels" "test" {
+  zone = local.zone
+  resource_type = "compute_instance"
+  resource_id = exoscale_compute_instance.test.id
+  labels = {
+    cost-center = "%s"
+  }
+}
+`
+)
+
+func TestMergeResourceLabels(t *testing.T) {
+	for _, tt := range []struct {
+		current, old, new, expected map[string]string
+	}{
+		{
+			current:  map[string]string{"owner": "ops"},
+			new:      map[string]string{"env": "prod"},
+			expected: map[string]string{"owner": "ops", "env": "prod"},
+		},
+		{
+			current:  map[string]string{"owner": "ops", "env": "prod", "tier": "web"},
+			old:      map[string]string{"env": "prod", "tier": "web"},
+			new:      map[string]string{"env": "dev"},
+			expected: map[string]string{"owner": "ops", "env": "dev"},
+		},
+		{
+			current:  map[string]string{"owner": "ops", "env": "prod"},
+			old:      map[string]string{"env": "prod"},
+			expected: map[string]string{"owner": "ops"},
+		},
+	} {
+		if actual := mergeResourceLabels(tt.current, tt.old, tt.new); !reflect.DeepEqual(actual, tt.expected) {
+			t.Errorf("bad labels, wanted %v, got %v", tt.expected, actual)
+		}
+	}
+}
+
+func TestAccResourceResourceLabels(t *testing.T) {
+	var (
+		r        = "exoscale_resource_labels.test"
+		instance exov2.Instance
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// Create
+				Config: fmt.Sprintf(
+					testAccResourceResourceLabelsConfig,
+					testZoneName,
+					testAccResourceResourceLabelsInstanceName,
+					testInstanceTemplateID,
+					"a",
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceComputeInstanceExists("exoscale_compute_instance.test", &instance),
+					func(s *terraform.State) error {
+						require.Equal(t, map[string]string{"owner": "terraform", "cost-center": "a"}, *instance.Labels)
+						return nil
+					},
+					checkResourceState(r, checkResourceStateValidateAttributes(testAttrs{
+						resResourceLabelsAttrLabels + ".%":           validateString("1"),
+						resResourceLabelsAttrLabels + ".cost-center": validateString("a"),
+					})),
+				),
+			},
+			{
+				// Update
+				Config: fmt.Sprintf(
+					testAccResourceResourceLabelsConfig,
+					testZoneName,
+					testAccResourceResourceLabelsInstanceName,
+					testInstanceTemplateID,
+					"b",
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceComputeInstanceExists("exoscale_compute_instance.test", &instance),
+					func(s *terraform.State) error {
+						require.Equal(t, map[string]string{"owner": "terraform", "cost-center": "b"}, *instance.Labels)
+						return nil
+					},
+				),
+			},
+			{
+				// Destroy the labels only, the other labels must be left untouched
+				Config: fmt.Sprintf(`
+locals {
+  zone = "%s"
+}
+
+resource "exoscale_compute_instance" "test" {
+  zone = local.zone
+  name = "%s"
+  type = "standard.tiny"
+  template_id = "%s"
+  disk_size = 10
+  labels = {
+    owner = "terraform"
+  }
+
+  lifecycle {
+    ignore_changes = [labels]
+  }
+
+  timeouts {
+    delete = "10m"
+  }
+}
+`,
+					testZoneName,
+					testAccResourceResourceLabelsInstanceName,
+					testInstanceTemplateID,
+				),
+				Check: func(s *terraform.State) error {
+					client := GetComputeClient(testAccProvider.Meta())
+					ctx := exoapi.WithEndpoint(
+						context.Background(),
+						exoapi.NewReqEndpoint(testEnvironment, testZoneName),
+					)
+
+					res, err := client.GetInstance(ctx, testZoneName, *instance.ID)
+					if err != nil {
+						return err
+					}
+
+					if res.Labels == nil || !reflect.DeepEqual(*res.Labels, map[string]string{"owner": "terraform"}) {
+						return errors.New("unexpected Compute instance labels after destroy")
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
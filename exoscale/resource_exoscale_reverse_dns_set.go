@@ -0,0 +1,387 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/exoscale/egoscale"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	resReverseDNSSetAttrRecords = "records"
+	resReverseDNSSetAttrZone    = "zone"
+)
+
+// reverseDNSTarget represents the resource holding the PTR record of an IP
+// address: either a Compute instance (whose IPv4 and IPv6 addresses share the
+// same PTR record) or an Elastic IP.
+type reverseDNSTarget struct {
+	elasticIP bool
+	id        string
+}
+
+func (t reverseDNSTarget) String() string {
+	if t.elasticIP {
+		return "Elastic IP " + t.id
+	}
+	return "Compute instance " + t.id
+}
+
+func resourceReverseDNSSetIDString(d resourceIDStringer) string {
+	return resourceIDString(d, "exoscale_reverse_dns_set")
+}
+
+func resourceReverseDNSSet() *schema.Resource {
+	s := map[string]*schema.Schema{
+		resReverseDNSSetAttrRecords: {
+			Type:        schema.TypeMap,
+			Required:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Map of IP addresses to the fully qualified domain name of their PTR record",
+		},
+		resReverseDNSSetAttrZone: {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+	}
+
+	return &schema.Resource{
+		Schema: s,
+
+		CreateContext: resourceReverseDNSSetCreate,
+		ReadContext:   resourceReverseDNSSetRead,
+		UpdateContext: resourceReverseDNSSetUpdate,
+		DeleteContext: resourceReverseDNSSetDelete,
+
+		CustomizeDiff: resourceReverseDNSSetCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+	}
+}
+
+func resourceReverseDNSSetCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	for ip, domain := range d.Get(resReverseDNSSetAttrRecords).(map[string]interface{}) {
+		if parsed := net.ParseIP(ip); parsed == nil || parsed.String() != ip {
+			return fmt.Errorf("invalid IP address %q in %s (IPv6 addresses must be in canonical form)",
+				ip, resReverseDNSSetAttrRecords)
+		}
+
+		if !strings.HasSuffix(domain.(string), ".") {
+			return fmt.Errorf("invalid domain name %q for IP address %s: "+
+				"must be a fully qualified domain name ending with a dot", domain, ip)
+		}
+	}
+
+	return nil
+}
+
+func resourceReverseDNSSetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning create", resourceReverseDNSSetIDString(d))
+
+	zone := d.Get(resReverseDNSSetAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	if err := reconcileReverseDNSSet(
+		ctx,
+		client,
+		zone,
+		map[string]interface{}{},
+		d.Get(resReverseDNSSetAttrRecords).(map[string]interface{}),
+	); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resource.UniqueId())
+
+	log.Printf("[DEBUG] %s: create finished successfully", resourceReverseDNSSetIDString(d))
+
+	return resourceReverseDNSSetRead(ctx, d, meta)
+}
+
+func resourceReverseDNSSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning read", resourceReverseDNSSetIDString(d))
+
+	zone := d.Get(resReverseDNSSetAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	targets, err := listReverseDNSTargets(ctx, client, zone)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	domains := make(map[reverseDNSTarget]string)
+	records := make(map[string]string)
+	for ip := range d.Get(resReverseDNSSetAttrRecords).(map[string]interface{}) {
+		target, ok := targets[ip]
+		if !ok {
+			// The resource holding the IP address doesn't exist anymore.
+			continue
+		}
+
+		domain, ok := domains[target]
+		if !ok {
+			if domain, err = queryReverseDNS(ctx, client, target); err != nil {
+				return diag.FromErr(err)
+			}
+			domains[target] = domain
+		}
+
+		if domain != "" {
+			records[ip] = domain
+		}
+	}
+
+	if err := d.Set(resReverseDNSSetAttrRecords, records); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: read finished successfully", resourceReverseDNSSetIDString(d))
+
+	return nil
+}
+
+func resourceReverseDNSSetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning update", resourceReverseDNSSetIDString(d))
+
+	zone := d.Get(resReverseDNSSetAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutUpdate))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	if d.HasChange(resReverseDNSSetAttrRecords) {
+		o, n := d.GetChange(resReverseDNSSetAttrRecords)
+		if err := reconcileReverseDNSSet(
+			ctx,
+			client,
+			zone,
+			o.(map[string]interface{}),
+			n.(map[string]interface{}),
+		); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Printf("[DEBUG] %s: update finished successfully", resourceReverseDNSSetIDString(d))
+
+	return resourceReverseDNSSetRead(ctx, d, meta)
+}
+
+func resourceReverseDNSSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning delete", resourceReverseDNSSetIDString(d))
+
+	zone := d.Get(resReverseDNSSetAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutDelete))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	if err := reconcileReverseDNSSet(
+		ctx,
+		client,
+		zone,
+		d.Get(resReverseDNSSetAttrRecords).(map[string]interface{}),
+		map[string]interface{}{},
+	); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: delete finished successfully", resourceReverseDNSSetIDString(d))
+
+	return nil
+}
+
+// reconcileReverseDNSSet applies the PTR records changes between the old and
+// new records, issuing a single request per Compute instance/Elastic IP.
+func reconcileReverseDNSSet(
+	ctx context.Context,
+	client *egoscale.Client,
+	zone string,
+	oldRecords, newRecords map[string]interface{},
+) error {
+	targets, err := listReverseDNSTargets(ctx, client, zone)
+	if err != nil {
+		return err
+	}
+
+	changes, err := reverseDNSSetChanges(targets, oldRecords, newRecords)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		log.Printf("[DEBUG] setting reverse DNS of %s to %q", change.target, change.domain)
+
+		if err := updateReverseDNS(ctx, client, change.target, change.domain); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type reverseDNSSetChange struct {
+	target reverseDNSTarget
+	domain string
+}
+
+// reverseDNSSetChanges returns the PTR records to set (or to delete, if the
+// domain is empty) to go from oldRecords to newRecords, sorted by target.
+// Records of IP addresses not held by any of the targets are ignored if they
+// are removed, and rejected if they are added.
+func reverseDNSSetChanges(
+	targets map[string]reverseDNSTarget,
+	oldRecords, newRecords map[string]interface{},
+) ([]reverseDNSSetChange, error) {
+	oldDomains := make(map[reverseDNSTarget]string)
+	for ip, domain := range oldRecords {
+		if target, ok := targets[ip]; ok {
+			oldDomains[target] = domain.(string)
+		}
+	}
+
+	newDomains := make(map[reverseDNSTarget]string)
+	for ip, domain := range newRecords {
+		target, ok := targets[ip]
+		if !ok {
+			return nil, fmt.Errorf("no Compute instance or Elastic IP found with IP address %s", ip)
+		}
+
+		if current, ok := newDomains[target]; ok && current != domain.(string) {
+			return nil, fmt.Errorf(
+				"conflicting domain names %q and %q for the IP addresses of %s, which share the same PTR record",
+				current, domain, target)
+		}
+		newDomains[target] = domain.(string)
+	}
+
+	changes := make([]reverseDNSSetChange, 0)
+	for target := range oldDomains {
+		if _, ok := newDomains[target]; !ok {
+			changes = append(changes, reverseDNSSetChange{target: target})
+		}
+	}
+	for target, domain := range newDomains {
+		if oldDomain, ok := oldDomains[target]; !ok || oldDomain != domain {
+			changes = append(changes, reverseDNSSetChange{target: target, domain: domain})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].target.String() < changes[j].target.String()
+	})
+
+	return changes, nil
+}
+
+// listReverseDNSTargets returns the Compute instances and Elastic IPs of a
+// zone, indexed by IP address.
+func listReverseDNSTargets(ctx context.Context, client *egoscale.Client, zone string) (map[string]reverseDNSTarget, error) {
+	targets := make(map[string]reverseDNSTarget)
+
+	instances, err := client.ListInstances(ctx, zone)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list Compute instances: %w", err)
+	}
+	for _, instance := range instances {
+		target := reverseDNSTarget{id: *instance.ID}
+		if instance.PublicIPAddress != nil {
+			targets[instance.PublicIPAddress.String()] = target
+		}
+		if instance.IPv6Address != nil {
+			targets[instance.IPv6Address.String()] = target
+		}
+	}
+
+	elasticIPs, err := client.ListElasticIPs(ctx, zone)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list Elastic IPs: %w", err)
+	}
+	for _, elasticIP := range elasticIPs {
+		if elasticIP.IPAddress != nil {
+			targets[elasticIP.IPAddress.String()] = reverseDNSTarget{elasticIP: true, id: *elasticIP.ID}
+		}
+	}
+
+	return targets, nil
+}
+
+func queryReverseDNS(ctx context.Context, client *egoscale.Client, target reverseDNSTarget) (string, error) {
+	id, err := egoscale.ParseUUID(target.id)
+	if err != nil {
+		return "", err
+	}
+
+	if target.elasticIP {
+		resp, err := client.RequestWithContext(ctx, &egoscale.QueryReverseDNSForPublicIPAddress{ID: id})
+		if err != nil {
+			return "", fmt.Errorf("unable to retrieve reverse DNS of %s: %s", target, err)
+		}
+		if ip := resp.(*egoscale.IPAddress); len(ip.ReverseDNS) > 0 {
+			return ip.ReverseDNS[0].DomainName, nil
+		}
+		return "", nil
+	}
+
+	resp, err := client.RequestWithContext(ctx, &egoscale.QueryReverseDNSForVirtualMachine{ID: id})
+	if err != nil {
+		return "", fmt.Errorf("unable to retrieve reverse DNS of %s: %s", target, err)
+	}
+	if nic := resp.(*egoscale.VirtualMachine).DefaultNic(); nic != nil && len(nic.ReverseDNS) > 0 {
+		return nic.ReverseDNS[0].DomainName, nil
+	}
+	return "", nil
+}
+
+func updateReverseDNS(ctx context.Context, client *egoscale.Client, target reverseDNSTarget, domain string) error {
+	if target.elasticIP {
+		return updateElasticIPReverseDNS(ctx, client, target.id, domain)
+	}
+
+	id, err := egoscale.ParseUUID(target.id)
+	if err != nil {
+		return err
+	}
+
+	var req egoscale.Command = &egoscale.DeleteReverseDNSFromVirtualMachine{ID: id}
+	if domain != "" {
+		req = &egoscale.UpdateReverseDNSForVirtualMachine{
+			ID:         id,
+			DomainName: domain,
+		}
+	}
+
+	if _, err := client.RequestWithContext(ctx, req); err != nil {
+		return fmt.Errorf("unable to update reverse DNS of %s: %s", target, err)
+	}
+
+	return nil
+}
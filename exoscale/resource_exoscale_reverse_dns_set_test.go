@@ -0,0 +1,178 @@
+package exoscale
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var (
+	testAccResourceReverseDNSSetInstanceName = acctest.RandomWithPrefix(testPrefix)
+
+	testAccResourceReverseDNSSetConfig = `
+locals {
+  zone = "%s"
+}
+
+resource "exoscale_compute_instance" "test" {
+  zone = local.zone
+  name = "%s"
+  type = "standard.tiny"
+  template_id = "%s"
+  disk_size = 10
+
+  timeouts {
+    delete = "10m"
+  }
+}
+
+resource "exoscale_elastic_ip" "test" {
+  zone = local.zone
+}
+
+resource "exoscale_reverse_dns_set" "test" {
+  zone = local.zone
+  records = {
+    (exoscale_compute_instance.test.public_ip_address) = "%s"
+    (exoscale_elastic_ip.test.ip_address) = "%s"
+  }
+}
+`
+)
+
+func TestReverseDNSSetChanges(t *testing.T) {
+	var (
+		instance  = reverseDNSTarget{id: "instance"}
+		elasticIP = reverseDNSTarget{elasticIP: true, id: "eip"}
+		targets   = map[string]reverseDNSTarget{
+			"192.0.2.1":   instance,
+			"2001:db8::1": instance,
+			"192.0.2.2":   elasticIP,
+		}
+	)
+
+	for _, tt := range []struct {
+		old, new map[string]interface{}
+		expected []reverseDNSSetChange
+		wantErr  bool
+	}{
+		{
+			old: map[string]interface{}{},
+			new: map[string]interface{}{"192.0.2.1": "a.example.net.", "2001:db8::1": "a.example.net."},
+			expected: []reverseDNSSetChange{
+				{target: instance, domain: "a.example.net."},
+			},
+		},
+		{
+			old: map[string]interface{}{"192.0.2.1": "a.example.net.", "192.0.2.2": "b.example.net."},
+			new: map[string]interface{}{"192.0.2.1": "a.example.net.", "192.0.2.2": "c.example.net."},
+			expected: []reverseDNSSetChange{
+				{target: elasticIP, domain: "c.example.net."},
+			},
+		},
+		{
+			old: map[string]interface{}{"192.0.2.1": "a.example.net.", "192.0.2.2": "b.example.net."},
+			new: map[string]interface{}{},
+			expected: []reverseDNSSetChange{
+				{target: instance},
+				{target: elasticIP},
+			},
+		},
+		{
+			// Resources holding removed IP addresses might not exist anymore.
+			old:      map[string]interface{}{"192.0.2.3": "a.example.net."},
+			new:      map[string]interface{}{},
+			expected: []reverseDNSSetChange{},
+		},
+		{
+			old:     map[string]interface{}{},
+			new:     map[string]interface{}{"192.0.2.3": "a.example.net."},
+			wantErr: true,
+		},
+		{
+			old:     map[string]interface{}{},
+			new:     map[string]interface{}{"192.0.2.1": "a.example.net.", "2001:db8::1": "b.example.net."},
+			wantErr: true,
+		},
+	} {
+		actual, err := reverseDNSSetChanges(targets, tt.old, tt.new)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("reverseDNSSetChanges(%v, %v) error = %v, wantErr %v", tt.old, tt.new, err, tt.wantErr)
+			continue
+		}
+
+		if !tt.wantErr && !reflect.DeepEqual(actual, tt.expected) {
+			t.Errorf("reverseDNSSetChanges(%v, %v) = %v, want %v", tt.old, tt.new, actual, tt.expected)
+		}
+	}
+}
+
+func TestAccResourceReverseDNSSet(t *testing.T) {
+	r := "exoscale_reverse_dns_set.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// Create
+				Config: fmt.Sprintf(
+					testAccResourceReverseDNSSetConfig,
+					testZoneName,
+					testAccResourceReverseDNSSetInstanceName,
+					testInstanceTemplateID,
+					"mx1.example.net.",
+					"mx.example.net.",
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceReverseDNSSetRecords(r, map[string]string{
+						"exoscale_compute_instance.test.public_ip_address": "mx1.example.net.",
+						"exoscale_elastic_ip.test.ip_address":              "mx.example.net.",
+					}),
+				),
+			},
+			{
+				// Update
+				Config: fmt.Sprintf(
+					testAccResourceReverseDNSSetConfig,
+					testZoneName,
+					testAccResourceReverseDNSSetInstanceName,
+					testInstanceTemplateID,
+					"mx2.example.net.",
+					"mx.example.net.",
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceReverseDNSSetRecords(r, map[string]string{
+						"exoscale_compute_instance.test.public_ip_address": "mx2.example.net.",
+						"exoscale_elastic_ip.test.ip_address":              "mx.example.net.",
+					}),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckResourceReverseDNSSetRecords checks that the records of the
+// exoscale_reverse_dns_set resource r match the expected domain names, indexed
+// by the "<RESOURCE>.<ATTRIBUTE>" holding the IP address.
+func testAccCheckResourceReverseDNSSetRecords(r string, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		want := testAttrs{resReverseDNSSetAttrRecords + ".%": validateString(fmt.Sprint(len(expected)))}
+
+		for ipAttr, domain := range expected {
+			i := strings.LastIndex(ipAttr, ".")
+			ip, err := attrFromState(s, ipAttr[:i], ipAttr[i+1:])
+			if err != nil {
+				return err
+			}
+			want[resReverseDNSSetAttrRecords+"."+ip] = validateString(domain)
+		}
+
+		return checkResourceState(r, checkResourceStateValidateAttributes(want))(s)
+	}
+}
@@ -16,10 +16,12 @@ func resourceSecurityGroup() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:     schema.TypeString,
-				ForceNew: true,
-				Required: true,
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Required:         true,
+				DiffSuppressFunc: suppressNameCollisionSuffix,
 			},
+			"name_collision_strategy": nameCollisionStrategySchema(),
 			"description": {
 				Type:     schema.TypeString,
 				ForceNew: true,
@@ -29,6 +31,7 @@ func resourceSecurityGroup() *schema.Resource {
 
 		Create: resourceSecurityGroupCreate,
 		Read:   resourceSecurityGroupRead,
+		Update: resourceSecurityGroupUpdate,
 		Delete: resourceSecurityGroupDelete,
 		Exists: resourceSecurityGroupExists,
 
@@ -52,18 +55,36 @@ func resourceSecurityGroupCreate(d *schema.ResourceData, meta interface{}) error
 
 	client := GetComputeClient(meta)
 
-	resp, err := client.RequestWithContext(ctx, &egoscale.CreateSecurityGroup{
-		Name:        d.Get("name").(string),
-		Description: d.Get("description").(string),
-	})
+	err := createWithNameCollisionStrategy(
+		d,
+		"exoscale_security_group",
+		func(name string) (string, error) {
+			resp, err := client.GetWithContext(ctx, &egoscale.SecurityGroup{Name: name})
+			if err != nil {
+				if isNotFoundError(err) {
+					return "", nil
+				}
+				return "", err
+			}
+			return resp.(*egoscale.SecurityGroup).ID.String(), nil
+		},
+		func(name string) error {
+			resp, err := client.RequestWithContext(ctx, &egoscale.CreateSecurityGroup{
+				Name:        name,
+				Description: d.Get("description").(string),
+			})
+			if err != nil {
+				return err
+			}
+
+			d.SetId(resp.(*egoscale.SecurityGroup).ID.String())
+			return nil
+		},
+	)
 	if err != nil {
 		return err
 	}
 
-	sg := resp.(*egoscale.SecurityGroup)
-
-	d.SetId(sg.ID.String())
-
 	log.Printf("[DEBUG] %s: create finished successfully", resourceSecurityGroupIDString(d))
 
 	return resourceSecurityGroupRead(d, meta)
@@ -120,6 +141,12 @@ func resourceSecurityGroupRead(d *schema.ResourceData, meta interface{}) error {
 	return resourceSecurityGroupApply(d, sg)
 }
 
+// resourceSecurityGroupUpdate only handles changes of the name collision
+// strategy, which doesn't apply to existing resources.
+func resourceSecurityGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceSecurityGroupRead(d, meta)
+}
+
 func resourceSecurityGroupDelete(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] %s: beginning delete", resourceSecurityGroupIDString(d))
 
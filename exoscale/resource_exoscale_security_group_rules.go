@@ -2,9 +2,12 @@ package exoscale
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
+	"net"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -32,6 +35,12 @@ func resourceSecurityGroupRules() *schema.Resource {
 						Type: schema.TypeString,
 					},
 				},
+				"allow_ping": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Description: "Allow ICMP echo requests (ICMPv6 for IPv6 CIDRs) from the rule sources, " +
+						"instead of the protocol/ports/icmp_type/icmp_code",
+				},
 				"description": {
 					Type:     schema.TypeString,
 					Optional: true,
@@ -97,8 +106,17 @@ func resourceSecurityGroupRules() *schema.Resource {
 			},
 			"ingress": ruleSchema,
 			"egress":  ruleSchema,
+			"max_rules": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "Maximum number of Security Group rules the ingress and egress blocks " +
+					"may expand to, checked at plan time",
+			},
 		},
 
+		CustomizeDiff: resourceSecurityGroupRulesCustomizeDiff,
+
 		Create: resourceSecurityGroupRulesCreate,
 		Read:   resourceSecurityGroupRulesRead,
 		Update: resourceSecurityGroupRulesUpdate,
@@ -229,13 +247,16 @@ func resourceSecurityGroupRulesRead(d *schema.ResourceData, meta interface{}) er
 
 	ingressRules := make(map[string]int, len(sg.IngressRule))
 	for i, rule := range sg.IngressRule {
-		id := ingressRuleToID(rule)
-		ingressRules[id] = i
+		ingressRules[rule.RuleID.String()] = i
 	}
 
 	if rules := d.Get("ingress").(*schema.Set); rules.Len() > 0 {
 		readRules(rules, func(identifier string) (*egoscale.IngressRule, bool) {
-			idx, ok := ingressRules[identifier]
+			id, err := parseSecurityGroupRuleID(identifier)
+			if err != nil {
+				return nil, false
+			}
+			idx, ok := ingressRules[id.String()]
 			if !ok {
 				return nil, false
 			}
@@ -248,13 +269,16 @@ func resourceSecurityGroupRulesRead(d *schema.ResourceData, meta interface{}) er
 
 	egressRules := make(map[string]int, len(sg.EgressRule))
 	for i, rule := range sg.EgressRule {
-		id := egressRuleToID(rule)
-		egressRules[id] = i
+		egressRules[rule.RuleID.String()] = i
 	}
 
 	if rules := d.Get("egress").(*schema.Set); rules.Len() > 0 {
 		readRules(rules, func(identifier string) (*egoscale.IngressRule, bool) {
-			idx, ok := egressRules[identifier]
+			id, err := parseSecurityGroupRuleID(identifier)
+			if err != nil {
+				return nil, false
+			}
+			idx, ok := egressRules[id.String()]
 			if !ok {
 				return nil, false
 			}
@@ -283,6 +307,11 @@ func resourceSecurityGroupRulesUpdate(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
+	existing, err := securityGroupRuleIDs(ctx, client, d)
+	if err != nil {
+		return err
+	}
+
 	if d.HasChange("ingress") {
 		o, n := d.GetChange("ingress")
 		old := o.(*schema.Set)
@@ -300,7 +329,7 @@ func resourceSecurityGroupRulesUpdate(d *schema.ResourceData, meta interface{})
 			}
 
 			for identifier, req := range reqs {
-				if err := client.BooleanRequestWithContext(ctx, req); err != nil {
+				if err := revokeSecurityGroupRule(ctx, client, req, identifier, existing); err != nil {
 					return err
 				}
 
@@ -351,7 +380,7 @@ func resourceSecurityGroupRulesUpdate(d *schema.ResourceData, meta interface{})
 			}
 
 			for identifier, req := range reqs {
-				if err := client.BooleanRequestWithContext(ctx, (egoscale.RevokeSecurityGroupEgress)(req)); err != nil {
+				if err := revokeSecurityGroupRule(ctx, client, (egoscale.RevokeSecurityGroupEgress)(req), identifier, existing); err != nil {
 					return err
 				}
 
@@ -399,6 +428,11 @@ func resourceSecurityGroupRulesDelete(d *schema.ResourceData, meta interface{})
 
 	client := GetComputeClient(meta)
 
+	existing, err := securityGroupRuleIDs(ctx, client, d)
+	if err != nil {
+		return err
+	}
+
 	if rules := d.Get("ingress").(*schema.Set); rules.Len() > 0 {
 		for _, r := range rules.List() {
 			rule := r.(map[string]interface{})
@@ -409,7 +443,7 @@ func resourceSecurityGroupRulesDelete(d *schema.ResourceData, meta interface{})
 			}
 
 			for identifier, req := range reqs {
-				if err := client.BooleanRequestWithContext(ctx, req); err != nil {
+				if err := revokeSecurityGroupRule(ctx, client, req, identifier, existing); err != nil {
 					return err
 				}
 
@@ -427,7 +461,7 @@ func resourceSecurityGroupRulesDelete(d *schema.ResourceData, meta interface{})
 				return err
 			}
 			for identifier, req := range reqs {
-				if err := client.BooleanRequestWithContext(ctx, (*egoscale.RevokeSecurityGroupEgress)(&req)); err != nil {
+				if err := revokeSecurityGroupRule(ctx, client, (*egoscale.RevokeSecurityGroupEgress)(&req), identifier, existing); err != nil {
 					return err
 				}
 
@@ -441,6 +475,67 @@ func resourceSecurityGroupRulesDelete(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
+// securityGroupRuleIDs returns the rule IDs of the ingress and egress rules
+// currently existing in the Security Group, which is considered empty if it
+// doesn't exist anymore.
+func securityGroupRuleIDs(ctx context.Context, client *egoscale.Client, d *schema.ResourceData) (map[string]struct{}, error) {
+	sg, err := inferSecurityGroup(d)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]struct{})
+
+	resp, err := client.GetWithContext(ctx, sg)
+	if err != nil {
+		if isNotFoundError(err) {
+			return ids, nil
+		}
+		return nil, err
+	}
+	sg = resp.(*egoscale.SecurityGroup)
+
+	for _, rule := range sg.IngressRule {
+		ids[rule.RuleID.String()] = struct{}{}
+	}
+	for _, rule := range sg.EgressRule {
+		ids[rule.RuleID.String()] = struct{}{}
+	}
+
+	return ids, nil
+}
+
+// revokeSecurityGroupRule revokes the Security Group rule identified by
+// identifier, unless it doesn't exist anymore (e.g. deleted outside of
+// Terraform while the state is stale).
+func revokeSecurityGroupRule(
+	ctx context.Context,
+	client *egoscale.Client,
+	req egoscale.Command,
+	identifier string,
+	existing map[string]struct{},
+) error {
+	id, err := parseSecurityGroupRuleID(identifier)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := existing[id.String()]; !ok {
+		log.Printf("[DEBUG] Security Group rule %s not found, skipping revocation", identifier)
+		return nil
+	}
+
+	if err := client.BooleanRequestWithContext(ctx, req); err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[DEBUG] Security Group rule %s not found, skipping revocation", identifier)
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
 // readRules performs the reconciliation of the rules using the ruleFunc
 func readRules(rules *schema.Set, ruleFunc fetchRuleFunc) {
 	for _, r := range rules.List() {
@@ -477,8 +572,12 @@ func readRules(rules *schema.Set, ruleFunc fetchRuleFunc) {
 			}
 			actualLen++
 
-			prot := strings.ToUpper(r.Protocol)
-			rule["protocol"] = prot
+			if current := ingressRuleToID(*r); current != id.(string) {
+				log.Printf("[DEBUG] re-fingerprinting Security Group rule identifier %s to %s", id, current)
+				ids.Remove(id)
+				ids.Add(current)
+			}
+
 			rule["description"] = r.Description
 			if r.CIDR != nil {
 				cidrList.Add(r.CIDR.String())
@@ -488,6 +587,14 @@ func readRules(rules *schema.Set, ruleFunc fetchRuleFunc) {
 				userSecurityGroupList.Add(r.SecurityGroupName)
 			}
 
+			// The protocol of the rules created by the allow_ping shorthand
+			// depends on their source, so the configured one is kept.
+			if rule["allow_ping"].(bool) {
+				continue
+			}
+
+			prot := strings.ToUpper(r.Protocol)
+			rule["protocol"] = prot
 			if strings.HasPrefix(prot, "ICMP") {
 				rule["protocol"] = strings.ReplaceAll(prot, "V6", "v6")
 				rule["icmp_code"] = r.IcmpCode
@@ -520,10 +627,36 @@ func readRules(rules *schema.Set, ruleFunc fetchRuleFunc) {
 	}
 }
 
+// Security Group rule identifiers, as stored in the "ids" attribute of the
+// ingress/egress rule blocks, have the following format:
+//
+//	<rule ID>_<version>_<fingerprint>
+//
+// where the fingerprint is a summary of the rule properties as normalized by
+// the API (e.g. "tcp_10.0.0.0/24_22-22" or "icmp_8:0"). Existing rules are
+// matched using the rule ID part only: the version and fingerprint parts are
+// recomputed on every read and updated in the state if they differ, so that
+// changes to the format or to the API normalization of the rule properties
+// don't require state migrations. Identifiers predating the versioned format
+// ("<rule ID>_<fingerprint>") are re-fingerprinted the same way.
+//
+// Any change to the fingerprint format must come with a version bump.
+const securityGroupRuleIDVersion = "v1"
+
 func ingressRuleToID(rule egoscale.IngressRule) string {
+	return fmt.Sprintf("%s_%s_%s", rule.RuleID, securityGroupRuleIDVersion, securityGroupRuleFingerprint(rule))
+}
+
+func egressRuleToID(rule egoscale.EgressRule) string {
+	return ingressRuleToID(egoscale.IngressRule(rule))
+}
+
+// securityGroupRuleFingerprint returns the fingerprint part of a Security
+// Group rule identifier.
+func securityGroupRuleFingerprint(rule egoscale.IngressRule) string {
 	p := strings.ToLower(rule.Protocol)
 	if strings.HasPrefix(p, "icmp") {
-		return fmt.Sprintf("%s_%s_%d:%d", rule.RuleID, p, rule.IcmpType, rule.IcmpCode)
+		return fmt.Sprintf("%s_%d:%d", p, rule.IcmpType, rule.IcmpCode)
 	}
 
 	name := rule.SecurityGroupName
@@ -531,21 +664,201 @@ func ingressRuleToID(rule egoscale.IngressRule) string {
 		name = rule.CIDR.String()
 	}
 
-	return fmt.Sprintf("%s_%s_%s_%d-%d", rule.RuleID, rule.Protocol, name, rule.StartPort, rule.EndPort)
+	return fmt.Sprintf("%s_%s_%d-%d", p, name, rule.StartPort, rule.EndPort)
 }
 
-func egressRuleToID(rule egoscale.EgressRule) string {
-	p := strings.ToLower(rule.Protocol)
-	if strings.HasPrefix(p, "icmp") {
-		return fmt.Sprintf("%s_%s_%d:%d", rule.RuleID, p, rule.IcmpType, rule.IcmpCode)
+// parseSecurityGroupRuleID returns the rule ID part of a Security Group rule
+// identifier, regardless of its format version.
+func parseSecurityGroupRuleID(identifier string) (*egoscale.UUID, error) {
+	return egoscale.ParseUUID(strings.SplitN(identifier, "_", 2)[0])
+}
+
+// resourceSecurityGroupRulesCustomizeDiff checks the total number of individual
+// API rules against the max_rules limit and logs a summary of the rules to be
+// added and removed, as a single rule block can expand to many of them (one
+// per port range and CIDR/Security Group source combination).
+func resourceSecurityGroupRulesCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	for _, direction := range []string{"ingress", "egress"} {
+		for _, r := range d.Get(direction).(*schema.Set).List() {
+			if err := validatePingRule(r.(map[string]interface{})); err != nil {
+				return fmt.Errorf("%s rule blocks with allow_ping set %s", direction, err)
+			}
+		}
 	}
 
-	name := rule.SecurityGroupName
-	if rule.CIDR != nil {
-		name = rule.CIDR.String()
+	var total int
+	for _, direction := range []string{"ingress", "egress"} {
+		total += len(expandSecurityGroupRules(d.Get(direction).(*schema.Set)))
+	}
+	log.Printf("[INFO] %s: rule blocks expand to %d Security Group rule(s)", resourceSecurityGroupRulesIDString(d), total)
+
+	if max, ok := d.GetOk("max_rules"); ok && total > max.(int) {
+		return fmt.Errorf(
+			"ingress and egress blocks expand to %d Security Group rules, exceeding max_rules (%d)",
+			total,
+			max.(int),
+		)
 	}
 
-	return fmt.Sprintf("%s_%s_%s_%d-%d", rule.RuleID, rule.Protocol, name, rule.StartPort, rule.EndPort)
+	for _, direction := range []string{"ingress", "egress"} {
+		if !d.HasChange(direction) {
+			continue
+		}
+
+		o, n := d.GetChange(direction)
+		if summary := securityGroupRulesChangeSummary(direction, o.(*schema.Set), n.(*schema.Set)); summary != "" {
+			log.Printf("[WARN] %s: %s", resourceSecurityGroupRulesIDString(d), summary)
+		}
+	}
+
+	return nil
+}
+
+// securityGroupRulesChangeSummary returns a human-readable summary of the API
+// rules added and removed between the old and new sets of rule blocks, counted
+// by protocol/port and by source. An empty string is returned if there are no
+// changes.
+func securityGroupRulesChangeSummary(direction string, o, n *schema.Set) string {
+	oldRules := expandSecurityGroupRules(o)
+	newRules := expandSecurityGroupRules(n)
+
+	byPort := make(map[string][2]int)
+	bySource := make(map[string][2]int)
+
+	count := func(rules, others map[string][2]string, i int) int {
+		var c int
+		for k, r := range rules {
+			if _, ok := others[k]; ok {
+				continue
+			}
+			c++
+
+			p := byPort[r[0]]
+			p[i]++
+			byPort[r[0]] = p
+
+			s := bySource[r[1]]
+			s[i]++
+			bySource[r[1]] = s
+		}
+		return c
+	}
+	added := count(newRules, oldRules, 0)
+	removed := count(oldRules, newRules, 1)
+
+	if added == 0 && removed == 0 {
+		return ""
+	}
+
+	format := func(counts map[string][2]int) string {
+		keys := make([]string, 0, len(counts))
+		for k := range counts {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		items := make([]string, len(keys))
+		for i, k := range keys {
+			var c []string
+			if counts[k][0] > 0 {
+				c = append(c, fmt.Sprintf("+%d", counts[k][0]))
+			}
+			if counts[k][1] > 0 {
+				c = append(c, fmt.Sprintf("-%d", counts[k][1]))
+			}
+			items[i] = fmt.Sprintf("%s (%s)", k, strings.Join(c, "/"))
+		}
+		return strings.Join(items, ", ")
+	}
+
+	return fmt.Sprintf(
+		"%s: %d API rule(s) to add, %d to remove; by protocol/port: %s; by source: %s",
+		direction,
+		added,
+		removed,
+		format(byPort),
+		format(bySource),
+	)
+}
+
+// expandSecurityGroupRules expands a set of rule blocks into the individual
+// API rules they represent, indexed by a unique key and described by their
+// protocol/port and source.
+func expandSecurityGroupRules(rules *schema.Set) map[string][2]string {
+	expanded := make(map[string][2]string)
+
+	for _, r := range rules.List() {
+		rule := r.(map[string]interface{})
+		protocol := strings.ToUpper(rule["protocol"].(string))
+
+		var ports []string
+		switch {
+		case rule["allow_ping"].(bool):
+			for _, source := range securityGroupRuleSources(rule) {
+				for _, p := range securityGroupPingRules(source) {
+					port := fmt.Sprintf("%s type %d code %d", p.protocol, p.icmpType, p.icmpCode)
+					expanded[port+" from "+source] = [2]string{port, source}
+				}
+			}
+			continue
+		case strings.HasPrefix(protocol, "ICMP"):
+			ports = []string{fmt.Sprintf("%s type %d code %d", protocol, rule["icmp_type"].(int), rule["icmp_code"].(int))}
+		case protocol == "AH" || protocol == "ESP" || protocol == "GRE" || protocol == "IPIP":
+			ports = []string{protocol}
+		default:
+			for _, p := range rule["ports"].(*schema.Set).List() {
+				ports = append(ports, fmt.Sprintf("%s %s", protocol, p.(string)))
+			}
+		}
+
+		for _, port := range ports {
+			for _, source := range securityGroupRuleSources(rule) {
+				expanded[port+" from "+source] = [2]string{port, source}
+			}
+		}
+	}
+
+	return expanded
+}
+
+// securityGroupRuleSources returns the sources of a rule block: its CIDRs, and
+// its Security Groups prefixed with "security group ".
+func securityGroupRuleSources(rule map[string]interface{}) []string {
+	var sources []string
+	for _, c := range rule["cidr_list"].(*schema.Set).List() {
+		sources = append(sources, c.(string))
+	}
+	for _, u := range rule["user_security_group_list"].(*schema.Set).List() {
+		sources = append(sources, "security group "+u.(string))
+	}
+
+	return sources
+}
+
+type securityGroupPingRule struct {
+	protocol string
+	icmpType int
+	icmpCode int
+}
+
+var (
+	securityGroupPingRuleICMP   = securityGroupPingRule{protocol: "ICMP", icmpType: 8}
+	securityGroupPingRuleICMPv6 = securityGroupPingRule{protocol: "ICMPv6", icmpType: 128}
+)
+
+// securityGroupPingRules returns the ICMP echo request rules allowing pings
+// from a source (as returned by securityGroupRuleSources): ICMP for IPv4
+// CIDRs, ICMPv6 for IPv6 CIDRs, and both for Security Groups.
+func securityGroupPingRules(source string) []securityGroupPingRule {
+	if strings.HasPrefix(source, "security group ") {
+		return []securityGroupPingRule{securityGroupPingRuleICMP, securityGroupPingRuleICMPv6}
+	}
+
+	if ip, _, err := net.ParseCIDR(source); err == nil && ip.To4() == nil {
+		return []securityGroupPingRule{securityGroupPingRuleICMPv6}
+	}
+
+	return []securityGroupPingRule{securityGroupPingRuleICMP}
 }
 
 func preparePorts(values *schema.Set) [][2]uint16 {
@@ -574,9 +887,7 @@ func ruleToRevoke(rule map[string]interface{}) (map[string]egoscale.RevokeSecuri
 	reqs := make(map[string]egoscale.RevokeSecurityGroupIngress, ids.Len())
 
 	for _, identifier := range ids.List() {
-		metas := strings.SplitN(identifier.(string), "_", 2)
-
-		id, err := egoscale.ParseUUID(metas[0])
+		id, err := parseSecurityGroupRuleID(identifier.(string))
 		if err != nil {
 			return nil, err
 		}
@@ -594,6 +905,10 @@ func ruleToAuthorize(ctx context.Context, client *egoscale.Client, rule map[stri
 	description := rule["description"].(string)
 	protocol := rule["protocol"].(string)
 
+	if rule["allow_ping"].(bool) {
+		return pingRuleToAuthorize(ctx, client, rule)
+	}
+
 	rs := []egoscale.AuthorizeSecurityGroupIngress{}
 
 	req := egoscale.AuthorizeSecurityGroupIngress{
@@ -638,25 +953,94 @@ func ruleToAuthorize(ctx context.Context, client *egoscale.Client, rule map[stri
 	userSecurityGroupSet := rule["user_security_group_list"].(*schema.Set)
 	for _, req := range rs {
 		for _, u := range userSecurityGroupSet.List() {
-			_, err := egoscale.ParseUUID(u.(string))
-			if err == nil {
-				return nil, fmt.Errorf("user_security_group_list must be referenced by name only, got ID %q", u.(string))
-			}
-
-			sg := &egoscale.SecurityGroup{
-				Name: u.(string),
-			}
-
-			resp, err := client.GetWithContext(ctx, sg)
+			userSecurityGroup, err := ruleUserSecurityGroup(ctx, client, u.(string))
 			if err != nil {
 				return nil, err
 			}
 
-			sg = resp.(*egoscale.SecurityGroup)
-			req.UserSecurityGroupList = []egoscale.UserSecurityGroup{sg.UserSecurityGroup()}
+			req.UserSecurityGroupList = []egoscale.UserSecurityGroup{userSecurityGroup}
 			reqs = append(reqs, req)
 		}
 	}
 
 	return reqs, nil
 }
+
+// validatePingRule checks that a rule having allow_ping set doesn't also
+// specify the properties the shorthand replaces, as they would be silently
+// ignored. The protocol can only be checked against its default value.
+func validatePingRule(rule map[string]interface{}) error {
+	if !rule["allow_ping"].(bool) {
+		return nil
+	}
+
+	switch {
+	case rule["ports"].(*schema.Set).Len() > 0:
+		return errors.New("cannot specify ports")
+	case !strings.EqualFold(rule["protocol"].(string), "TCP"):
+		return errors.New("cannot specify protocol")
+	case rule["icmp_type"].(int) != 0, rule["icmp_code"].(int) != 0:
+		return errors.New("cannot specify icmp_type or icmp_code")
+	}
+
+	return nil
+}
+
+// pingRuleToAuthorize converts a rule having allow_ping set into a list of
+// ICMP/ICMPv6 echo request authorize requests.
+func pingRuleToAuthorize(ctx context.Context, client *egoscale.Client, rule map[string]interface{}) ([]egoscale.AuthorizeSecurityGroupIngress, error) {
+	reqs := []egoscale.AuthorizeSecurityGroupIngress{}
+
+	newReq := func(p securityGroupPingRule) egoscale.AuthorizeSecurityGroupIngress {
+		return egoscale.AuthorizeSecurityGroupIngress{
+			Description: rule["description"].(string),
+			Protocol:    p.protocol,
+			IcmpType:    p.icmpType,
+			IcmpCode:    p.icmpCode,
+		}
+	}
+
+	for _, c := range rule["cidr_list"].(*schema.Set).List() {
+		cidr, err := egoscale.ParseCIDR(c.(string))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range securityGroupPingRules(c.(string)) {
+			req := newReq(p)
+			req.CIDRList = []egoscale.CIDR{*cidr}
+			reqs = append(reqs, req)
+		}
+	}
+
+	for _, u := range rule["user_security_group_list"].(*schema.Set).List() {
+		userSecurityGroup, err := ruleUserSecurityGroup(ctx, client, u.(string))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range securityGroupPingRules("security group " + u.(string)) {
+			req := newReq(p)
+			req.UserSecurityGroupList = []egoscale.UserSecurityGroup{userSecurityGroup}
+			reqs = append(reqs, req)
+		}
+	}
+
+	return reqs, nil
+}
+
+// ruleUserSecurityGroup returns the Security Group referenced by name in the
+// user_security_group_list of a rule.
+func ruleUserSecurityGroup(ctx context.Context, client *egoscale.Client, name string) (egoscale.UserSecurityGroup, error) {
+	if _, err := egoscale.ParseUUID(name); err == nil {
+		return egoscale.UserSecurityGroup{}, fmt.Errorf(
+			"user_security_group_list must be referenced by name only, got ID %q", name)
+	}
+
+	resp, err := client.GetWithContext(ctx, &egoscale.SecurityGroup{Name: name})
+	if err != nil {
+		return egoscale.UserSecurityGroup{}, err
+	}
+
+	return resp.(*egoscale.SecurityGroup).UserSecurityGroup(), nil
+}
@@ -92,6 +92,10 @@ resource "exoscale_security_group_rules" "rules" {
     ports = ["4444", "2375-2377"]
     user_security_group_list = ["default"]
   }
+  egress {
+    allow_ping = true
+    cidr_list = ["10.1.0.0/24", "fd00::/8"]
+  }
 }
 `,
 		testAccResourceSecurityGroupRulesSecurityGroupName,
@@ -112,6 +116,183 @@ func TestPreparePorts(t *testing.T) {
 	}
 }
 
+func TestSecurityGroupRulesChangeSummary(t *testing.T) {
+	ruleHash := schema.HashResource(resourceSecurityGroupRules().Schema["ingress"].Elem.(*schema.Resource))
+
+	newRule := func(protocol string, ports, cidrs, groups []interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"ids":                      schema.NewSet(schema.HashString, nil),
+			"allow_ping":               false,
+			"description":              "",
+			"protocol":                 protocol,
+			"ports":                    schema.NewSet(schema.HashString, ports),
+			"cidr_list":                schema.NewSet(schema.HashString, cidrs),
+			"user_security_group_list": schema.NewSet(schema.HashString, groups),
+			"icmp_type":                0,
+			"icmp_code":                0,
+		}
+	}
+
+	o := schema.NewSet(ruleHash, []interface{}{
+		newRule("TCP", []interface{}{"22", "443"}, []interface{}{"10.0.0.0/24", "::/0"}, []interface{}{"default"}),
+	})
+	n := schema.NewSet(ruleHash, []interface{}{
+		newRule("TCP", []interface{}{"2222", "443"}, []interface{}{"10.0.0.0/24", "::/0"}, []interface{}{"default"}),
+	})
+
+	if summary := securityGroupRulesChangeSummary("ingress", o, o); summary != "" {
+		t.Errorf("expected no summary for unchanged rules, got %q", summary)
+	}
+
+	expected := "ingress: 3 API rule(s) to add, 3 to remove; " +
+		"by protocol/port: TCP 22 (-3), TCP 2222 (+3); " +
+		"by source: 10.0.0.0/24 (+1/-1), ::/0 (+1/-1), security group default (+1/-1)"
+	if summary := securityGroupRulesChangeSummary("ingress", o, n); summary != expected {
+		t.Errorf("bad summary, wanted %q, got %q", expected, summary)
+	}
+
+	ping := newRule("TCP", nil, []interface{}{"10.0.0.0/24", "::/0"}, []interface{}{"default"})
+	ping["allow_ping"] = true
+	expected = "ingress: 4 API rule(s) to add, 0 to remove; " +
+		"by protocol/port: ICMP type 8 code 0 (+2), ICMPv6 type 128 code 0 (+2); " +
+		"by source: 10.0.0.0/24 (+1), ::/0 (+1), security group default (+2)"
+	if summary := securityGroupRulesChangeSummary(
+		"ingress",
+		schema.NewSet(ruleHash, nil),
+		schema.NewSet(ruleHash, []interface{}{ping}),
+	); summary != expected {
+		t.Errorf("bad summary, wanted %q, got %q", expected, summary)
+	}
+}
+
+func TestValidatePingRule(t *testing.T) {
+	newRule := func(protocol string, ports []interface{}, icmpType, icmpCode int) map[string]interface{} {
+		return map[string]interface{}{
+			"allow_ping": true,
+			"protocol":   protocol,
+			"ports":      schema.NewSet(schema.HashString, ports),
+			"icmp_type":  icmpType,
+			"icmp_code":  icmpCode,
+		}
+	}
+
+	for _, tt := range []struct {
+		rule    map[string]interface{}
+		wantErr bool
+	}{
+		{rule: newRule("TCP", nil, 0, 0)},
+		{rule: newRule("TCP", []interface{}{"22"}, 0, 0), wantErr: true},
+		{rule: newRule("ICMP", nil, 0, 0), wantErr: true},
+		{rule: newRule("TCP", nil, 8, 0), wantErr: true},
+		{rule: newRule("TCP", nil, 0, -1), wantErr: true},
+	} {
+		if err := validatePingRule(tt.rule); (err != nil) != tt.wantErr {
+			t.Errorf("validatePingRule(%v): wanted error %v, got %v", tt.rule, tt.wantErr, err)
+		}
+	}
+
+	rule := newRule("ICMP", []interface{}{"22"}, 8, 0)
+	rule["allow_ping"] = false
+	if err := validatePingRule(rule); err != nil {
+		t.Errorf("expected no error for a rule without allow_ping, got %v", err)
+	}
+}
+
+func TestSecurityGroupRuleID(t *testing.T) {
+	ruleID := egoscale.MustParseUUID("6d9f6b6e-4ab5-4b61-a4b1-48d1a3a4a1e2")
+	cidr := egoscale.MustParseCIDR("10.0.0.0/24")
+
+	for _, tt := range []struct {
+		rule     egoscale.IngressRule
+		expected string
+	}{
+		{
+			rule: egoscale.IngressRule{
+				RuleID:    ruleID,
+				Protocol:  "tcp",
+				CIDR:      cidr,
+				StartPort: 22,
+				EndPort:   22,
+			},
+			expected: ruleID.String() + "_v1_tcp_10.0.0.0/24_22-22",
+		},
+		{
+			rule: egoscale.IngressRule{
+				RuleID:            ruleID,
+				Protocol:          "UDP",
+				SecurityGroupName: "default",
+				StartPort:         1000,
+				EndPort:           2000,
+			},
+			expected: ruleID.String() + "_v1_udp_default_1000-2000",
+		},
+		{
+			rule: egoscale.IngressRule{
+				RuleID:   ruleID,
+				Protocol: "ICMPv6",
+				IcmpType: 128,
+			},
+			expected: ruleID.String() + "_v1_icmpv6_128:0",
+		},
+	} {
+		identifier := ingressRuleToID(tt.rule)
+		if identifier != tt.expected {
+			t.Errorf("bad identifier, wanted %q, got %q", tt.expected, identifier)
+		}
+
+		id, err := parseSecurityGroupRuleID(identifier)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !id.Equal(*ruleID) {
+			t.Errorf("bad rule ID, wanted %q, got %q", ruleID, id)
+		}
+	}
+}
+
+func TestReadRulesRefingerprint(t *testing.T) {
+	rule := egoscale.IngressRule{
+		RuleID:    egoscale.MustParseUUID("6d9f6b6e-4ab5-4b61-a4b1-48d1a3a4a1e2"),
+		Protocol:  "tcp",
+		CIDR:      egoscale.MustParseCIDR("10.0.0.0/24"),
+		StartPort: 22,
+		EndPort:   22,
+	}
+	// Identifier stored in the state before the versioned format.
+	legacyID := rule.RuleID.String() + "_tcp_10.0.0.0/24_22-22"
+
+	rules := schema.NewSet(
+		schema.HashResource(resourceSecurityGroupRules().Schema["ingress"].Elem.(*schema.Resource)),
+		[]interface{}{map[string]interface{}{
+			"ids":                      schema.NewSet(schema.HashString, []interface{}{legacyID}),
+			"allow_ping":               false,
+			"description":              "",
+			"protocol":                 "TCP",
+			"ports":                    schema.NewSet(schema.HashString, []interface{}{"22"}),
+			"cidr_list":                schema.NewSet(schema.HashString, []interface{}{"10.0.0.0/24"}),
+			"user_security_group_list": schema.NewSet(schema.HashString, nil),
+			"icmp_type":                0,
+			"icmp_code":                0,
+		}},
+	)
+
+	readRules(rules, func(identifier string) (*egoscale.IngressRule, bool) {
+		id, err := parseSecurityGroupRuleID(identifier)
+		if err != nil || !id.Equal(*rule.RuleID) {
+			return nil, false
+		}
+		return &rule, true
+	})
+
+	if rules.Len() != 1 {
+		t.Fatalf("expected 1 rule, got %d", rules.Len())
+	}
+	ids := rules.List()[0].(map[string]interface{})["ids"].(*schema.Set).List()
+	if len(ids) != 1 || ids[0].(string) != ingressRuleToID(rule) {
+		t.Errorf("bad ids, wanted [%s], got %v", ingressRuleToID(rule), ids)
+	}
+}
+
 func TestAccResourceSecurityGroupRules(t *testing.T) {
 	sg := new(egoscale.SecurityGroup)
 
@@ -227,7 +408,19 @@ func TestAccResourceSecurityGroupRules(t *testing.T) {
 				Config: testAccResourceSecurityGroupRulesConfigUpdate,
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckResourceSecurityGroupExists("exoscale_security_group.sg", sg),
-					testAccCheckSecurityGroupHasManyRules(16),
+					testAccCheckSecurityGroupHasManyRules(18),
+					testAccCheckSecurityGroupEgressRuleExists(sg, &egoscale.EgressRule{
+						CIDR:     egoscale.MustParseCIDR("10.1.0.0/24"),
+						Protocol: "ICMP",
+						IcmpType: 8,
+						IcmpCode: 0,
+					}),
+					testAccCheckSecurityGroupEgressRuleExists(sg, &egoscale.EgressRule{
+						CIDR:     egoscale.MustParseCIDR("fd00::/8"),
+						Protocol: "ICMPv6",
+						IcmpType: 128,
+						IcmpCode: 0,
+					}),
 					testAccCheckSecurityGroupIngressRuleExists(sg, &egoscale.IngressRule{
 						SecurityGroupName: testAccResourceSecurityGroupRulesSecurityGroupName,
 						StartPort:         2222,
@@ -45,9 +45,10 @@ func TestAccResourceSecurityGroup(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      "exoscale_security_group.sg",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "exoscale_security_group.sg",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"name_collision_strategy"},
 				ImportStateCheck: func(s []*terraform.InstanceState) error {
 					return checkResourceAttributes(
 						testAttrs{
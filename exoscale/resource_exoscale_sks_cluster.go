@@ -3,35 +3,45 @@ package exoscale
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
+	"time"
 
+	"github.com/exoscale/egoscale"
 	exov2 "github.com/exoscale/egoscale/v2"
 	exoapi "github.com/exoscale/egoscale/v2/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
 	defaultSKSClusterCNI          = "calico"
 	defaultSKSClusterServiceLevel = "pro"
 
+	sksClusterServiceLevelPro     = "pro"
+	sksClusterServiceLevelStarter = "starter"
+
 	sksClusterAddonExoscaleCCM = "exoscale-cloud-controller"
 	sksClusterAddonMS          = "metrics-server"
 
-	resSKSClusterAttrAddons        = "addons"
-	resSKSClusterAttrAutoUpgrade   = "auto_upgrade"
-	resSKSClusterAttrCNI           = "cni"
-	resSKSClusterAttrCreatedAt     = "created_at"
-	resSKSClusterAttrDescription   = "description"
-	resSKSClusterAttrEndpoint      = "endpoint"
-	resSKSClusterAttrExoscaleCCM   = "exoscale_ccm"
-	resSKSClusterAttrMetricsServer = "metrics_server"
-	resSKSClusterAttrName          = "name"
-	resSKSClusterAttrNodepools     = "nodepools"
-	resSKSClusterAttrServiceLevel  = "service_level"
-	resSKSClusterAttrState         = "state"
-	resSKSClusterAttrVersion       = "version"
-	resSKSClusterAttrZone          = "zone"
+	resSKSClusterAttrAddons           = "addons"
+	resSKSClusterAttrAutoUpgrade      = "auto_upgrade"
+	resSKSClusterAttrCNI              = "cni"
+	resSKSClusterAttrCreatedAt        = "created_at"
+	resSKSClusterAttrDescription      = "description"
+	resSKSClusterAttrEndpoint         = "endpoint"
+	resSKSClusterAttrExoscaleCCM      = "exoscale_ccm"
+	resSKSClusterAttrLatestVersion    = "latest_version"
+	resSKSClusterAttrMetricsServer    = "metrics_server"
+	resSKSClusterAttrName             = "name"
+	resSKSClusterAttrNodepools        = "nodepools"
+	resSKSClusterAttrServiceLevel     = "service_level"
+	resSKSClusterAttrState            = "state"
+	resSKSClusterAttrUpgradeAvailable = "upgrade_available"
+	resSKSClusterAttrVersion          = "version"
+	resSKSClusterAttrZone             = "zone"
 )
 
 func resourceSKSClusterIDString(d resourceIDStringer) string {
@@ -75,6 +85,10 @@ func resourceSKSCluster() *schema.Resource {
 			Optional: true,
 			Default:  true,
 		},
+		resSKSClusterAttrLatestVersion: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
 		resSKSClusterAttrMetricsServer: {
 			Type:     schema.TypeBool,
 			Optional: true,
@@ -94,11 +108,19 @@ func resourceSKSCluster() *schema.Resource {
 			Type:     schema.TypeString,
 			Optional: true,
 			Default:  defaultSKSClusterServiceLevel,
+			ValidateFunc: validation.StringInSlice([]string{
+				sksClusterServiceLevelPro,
+				sksClusterServiceLevelStarter,
+			}, false),
 		},
 		resSKSClusterAttrState: {
 			Type:     schema.TypeString,
 			Computed: true,
 		},
+		resSKSClusterAttrUpgradeAvailable: {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
 		resSKSClusterAttrVersion: {
 			Type:     schema.TypeString,
 			Optional: true,
@@ -119,6 +141,8 @@ func resourceSKSCluster() *schema.Resource {
 		UpdateContext: resourceSKSClusterUpdate,
 		DeleteContext: resourceSKSClusterDelete,
 
+		CustomizeDiff: resourceSKSClusterCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: zonedStateContextFunc,
 		},
@@ -234,7 +258,26 @@ func resourceSKSClusterRead(ctx context.Context, d *schema.ResourceData, meta in
 
 	log.Printf("[DEBUG] %s: read finished successfully", resourceSKSClusterIDString(d))
 
-	return resourceSKSClusterApply(ctx, d, sksCluster)
+	if diags := resourceSKSClusterApply(ctx, d, sksCluster); diags.HasError() {
+		return diags
+	}
+
+	// The available versions are informational only: failing to retrieve
+	// them must not prevent refreshing the cluster, the previous values of
+	// the related attributes are kept instead.
+	versions, err := client.ListSKSClusterVersions(ctx)
+	if err != nil {
+		log.Printf(
+			"[WARN] %s: unable to retrieve SKS versions, keeping previous %s/%s values: %s",
+			resourceSKSClusterIDString(d),
+			resSKSClusterAttrLatestVersion,
+			resSKSClusterAttrUpgradeAvailable,
+			err,
+		)
+		return nil
+	}
+
+	return resourceSKSClusterApplyVersions(d, versions)
 }
 
 func resourceSKSClusterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -279,11 +322,94 @@ func resourceSKSClusterUpdate(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 
+	if d.HasChange(resSKSClusterAttrServiceLevel) {
+		if _, err = client.UpgradeSksClusterServiceLevelWithResponse(ctx, d.Id()); err != nil {
+			return diag.Errorf("unable to upgrade SKS cluster service level: %s", err)
+		}
+
+		if err = waitForSKSClusterServiceLevel(ctx, d, client, zone); err != nil {
+			return diag.Errorf("unable to wait for SKS cluster service level upgrade: %s", err)
+		}
+	}
+
 	log.Printf("[DEBUG] %s: update finished successfully", resourceSKSClusterIDString(d))
 
 	return resourceSKSClusterRead(ctx, d, meta)
 }
 
+// resourceSKSClusterCustomizeDiff logs the implications of a service level
+// change, which is performed in place when upgrading from starter to pro and
+// requires re-creating the cluster otherwise, as the API only supports
+// upgrades.
+func resourceSKSClusterCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange(resSKSClusterAttrServiceLevel) {
+		return nil
+	}
+
+	o, n := d.GetChange(resSKSClusterAttrServiceLevel)
+	if err := validateSKSClusterServiceLevelChange(o.(string), n.(string)); err != nil {
+		return err
+	}
+
+	log.Printf("[WARN] %s: service level upgrade from starter to pro will be performed in place: "+
+		"the cluster will be covered by the pro SLA and billed accordingly",
+		resourceSKSClusterIDString(d))
+
+	return nil
+}
+
+// validateSKSClusterServiceLevelChange returns an error if an existing SKS
+// cluster service level cannot be changed from o to n: only upgrades from
+// starter to pro are supported by the API, and re-creating the cluster (along
+// with its workloads) to downgrade it is never done implicitly.
+func validateSKSClusterServiceLevelChange(o, n string) error {
+	if o == sksClusterServiceLevelStarter && n == sksClusterServiceLevelPro {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"changing the service level of an existing SKS cluster from %s to %s is not supported: "+
+			"only upgrades from %s to %s can be performed in place "+
+			"(re-create the cluster explicitly, e.g. using \"terraform apply -replace\", to downgrade it)",
+		o,
+		n,
+		sksClusterServiceLevelStarter,
+		sksClusterServiceLevelPro,
+	)
+}
+
+// waitForSKSClusterServiceLevel waits until the SKS cluster service level
+// matches the service_level attribute value.
+func waitForSKSClusterServiceLevel(
+	ctx context.Context,
+	d *schema.ResourceData,
+	client *egoscale.Client,
+	zone string,
+) error {
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	progress := newWaitProgress(resourceSKSClusterIDString(d), "waiting for service level upgrade", timeout)
+
+	_, err := (&resource.StateChangeConf{
+		Pending: []string{sksClusterServiceLevelStarter},
+		Target:  []string{d.Get(resSKSClusterAttrServiceLevel).(string)},
+		Refresh: func() (interface{}, string, error) {
+			sksCluster, err := client.GetSKSCluster(ctx, zone, d.Id())
+			if err != nil {
+				return nil, "", err
+			}
+
+			progress.report(defaultString(sksCluster.ServiceLevel, ""))
+
+			return sksCluster, defaultString(sksCluster.ServiceLevel, ""), nil
+		},
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+		Timeout:    timeout,
+	}).WaitForStateContext(ctx)
+
+	return err
+}
+
 func resourceSKSClusterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[DEBUG] %s: beginning delete", resourceSKSClusterIDString(d))
 
@@ -366,3 +492,25 @@ func resourceSKSClusterApply(_ context.Context, d *schema.ResourceData, sksClust
 
 	return nil
 }
+
+// resourceSKSClusterApplyVersions sets the latest Kubernetes version available
+// for SKS clusters, and whether the cluster control plane can be upgraded to
+// it. versions are expected to be sorted by decreasing order, as returned by
+// the API.
+func resourceSKSClusterApplyVersions(d *schema.ResourceData, versions []string) diag.Diagnostics {
+	var latestVersion string
+	if len(versions) > 0 {
+		latestVersion = versions[0]
+	}
+
+	if err := d.Set(resSKSClusterAttrLatestVersion, latestVersion); err != nil {
+		return diag.FromErr(err)
+	}
+
+	upgradeAvailable := latestVersion != "" && latestVersion != d.Get(resSKSClusterAttrVersion).(string)
+	if err := d.Set(resSKSClusterAttrUpgradeAvailable, upgradeAvailable); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
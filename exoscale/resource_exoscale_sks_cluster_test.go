@@ -93,6 +93,39 @@ resource "exoscale_sks_nodepool" "test" {
 	)
 )
 
+func TestResourceSKSClusterApplyVersions(t *testing.T) {
+	for _, tt := range []struct {
+		version                  string
+		versions                 []string
+		expectedLatestVersion    string
+		expectedUpgradeAvailable bool
+	}{
+		{version: "1.20.2", versions: []string{"1.20.2", "1.19.7"}, expectedLatestVersion: "1.20.2"},
+		{
+			version:                  "1.19.7",
+			versions:                 []string{"1.20.2", "1.19.7"},
+			expectedLatestVersion:    "1.20.2",
+			expectedUpgradeAvailable: true,
+		},
+		{version: "1.19.7", versions: []string{}},
+	} {
+		d := resourceSKSCluster().TestResourceData()
+		_ = d.Set(resSKSClusterAttrVersion, tt.version)
+
+		if diags := resourceSKSClusterApplyVersions(d, tt.versions); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+
+		assert.Equal(t, tt.expectedLatestVersion, d.Get(resSKSClusterAttrLatestVersion).(string))
+		assert.Equal(t, tt.expectedUpgradeAvailable, d.Get(resSKSClusterAttrUpgradeAvailable).(bool))
+	}
+}
+
+func TestValidateSKSClusterServiceLevelChange(t *testing.T) {
+	assert.NoError(t, validateSKSClusterServiceLevelChange(sksClusterServiceLevelStarter, sksClusterServiceLevelPro))
+	assert.Error(t, validateSKSClusterServiceLevelChange(sksClusterServiceLevelPro, sksClusterServiceLevelStarter))
+}
+
 func TestAccResourceSKSCluster(t *testing.T) {
 	var (
 		r          = "exoscale_sks_cluster.test"
@@ -120,12 +153,7 @@ func TestAccResourceSKSCluster(t *testing.T) {
 						client, err := exov2.NewClient(
 							os.Getenv("EXOSCALE_API_KEY"),
 							os.Getenv("EXOSCALE_API_SECRET"),
-							exov2.ClientOptCond(func() bool {
-								if v := os.Getenv("EXOSCALE_TRACE"); v != "" {
-									return true
-								}
-								return false
-							}, exov2.ClientOptWithTrace()))
+							exov2.ClientOptCond(traceEnabled, exov2.ClientOptWithTrace()))
 						if err != nil {
 							return fmt.Errorf("unable to initialize Exoscale client: %s", err)
 						}
@@ -154,17 +182,19 @@ func TestAccResourceSKSCluster(t *testing.T) {
 						return nil
 					},
 					checkResourceState(r, checkResourceStateValidateAttributes(testAttrs{
-						resSKSClusterAttrAutoUpgrade:   validateString("true"),
-						resSKSClusterAttrCNI:           validateString(defaultSKSClusterCNI),
-						resSKSClusterAttrCreatedAt:     validation.ToDiagFunc(validation.NoZeroValues),
-						resSKSClusterAttrDescription:   validateString(testAccResourceSKSClusterDescription),
-						resSKSClusterAttrEndpoint:      validation.ToDiagFunc(validation.IsURLWithHTTPS),
-						resSKSClusterAttrExoscaleCCM:   validateString("true"),
-						resSKSClusterAttrMetricsServer: validateString("false"),
-						resSKSClusterAttrName:          validateString(testAccResourceSKSClusterName),
-						resSKSClusterAttrServiceLevel:  validateString(defaultSKSClusterServiceLevel),
-						resSKSClusterAttrState:         validation.ToDiagFunc(validation.NoZeroValues),
-						resSKSClusterAttrVersion:       validation.ToDiagFunc(validation.NoZeroValues),
+						resSKSClusterAttrAutoUpgrade:      validateString("true"),
+						resSKSClusterAttrCNI:              validateString(defaultSKSClusterCNI),
+						resSKSClusterAttrCreatedAt:        validation.ToDiagFunc(validation.NoZeroValues),
+						resSKSClusterAttrDescription:      validateString(testAccResourceSKSClusterDescription),
+						resSKSClusterAttrEndpoint:         validation.ToDiagFunc(validation.IsURLWithHTTPS),
+						resSKSClusterAttrExoscaleCCM:      validateString("true"),
+						resSKSClusterAttrLatestVersion:    validation.ToDiagFunc(validation.NoZeroValues),
+						resSKSClusterAttrMetricsServer:    validateString("false"),
+						resSKSClusterAttrName:             validateString(testAccResourceSKSClusterName),
+						resSKSClusterAttrServiceLevel:     validateString(defaultSKSClusterServiceLevel),
+						resSKSClusterAttrState:            validation.ToDiagFunc(validation.NoZeroValues),
+						resSKSClusterAttrUpgradeAvailable: validateString("false"),
+						resSKSClusterAttrVersion:          validation.ToDiagFunc(validation.NoZeroValues),
 					})),
 				),
 			},
@@ -189,6 +219,7 @@ func TestAccResourceSKSCluster(t *testing.T) {
 						resSKSClusterAttrDescription:   validation.ToDiagFunc(validation.StringIsEmpty),
 						resSKSClusterAttrEndpoint:      validation.ToDiagFunc(validation.IsURLWithHTTPS),
 						resSKSClusterAttrExoscaleCCM:   validateString("true"),
+						resSKSClusterAttrLatestVersion: validation.ToDiagFunc(validation.NoZeroValues),
 						resSKSClusterAttrMetricsServer: validateString("false"),
 						resSKSClusterAttrName:          validateString(testAccResourceSKSClusterNameUpdated),
 						resSKSClusterAttrServiceLevel:  validateString(defaultSKSClusterServiceLevel),
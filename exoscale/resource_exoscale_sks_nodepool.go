@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 
 	"github.com/exoscale/egoscale"
@@ -19,6 +20,7 @@ const (
 	defaultSKSNodepoolInstancePrefix       = "pool"
 
 	resSKSNodepoolAttrAntiAffinityGroupIDs = "anti_affinity_group_ids"
+	resSKSNodepoolAttrCleanup              = "cleanup_external_resources"
 	resSKSNodepoolAttrClusterID            = "cluster_id"
 	resSKSNodepoolAttrCreatedAt            = "created_at"
 	resSKSNodepoolAttrDeployTargetID       = "deploy_target_id"
@@ -49,6 +51,10 @@ func resourceSKSNodepool() *schema.Resource {
 			Set:      schema.HashString,
 			Elem:     &schema.Schema{Type: schema.TypeString},
 		},
+		resSKSNodepoolAttrCleanup: {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
 		resSKSNodepoolAttrClusterID: {
 			Type:     schema.TypeString,
 			Required: true,
@@ -214,7 +220,7 @@ func resourceSKSNodepoolCreate(ctx context.Context, d *schema.ResourceData, meta
 		sksNodepool.InstancePrefix = &s
 	}
 
-	instanceType, err := client.FindInstanceType(ctx, zone, d.Get(resSKSNodepoolAttrInstanceType).(string))
+	instanceType, err := findInstanceType(ctx, client, zone, d.Get(resSKSNodepoolAttrInstanceType).(string))
 	if err != nil {
 		return diag.Errorf("error retrieving instance type: %s", err)
 	}
@@ -373,7 +379,7 @@ func resourceSKSNodepoolUpdate(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	if d.HasChange(resSKSNodepoolAttrInstanceType) {
-		instanceType, err := client.FindInstanceType(ctx, zone, d.Get(resSKSNodepoolAttrInstanceType).(string))
+		instanceType, err := findInstanceType(ctx, client, zone, d.Get(resSKSNodepoolAttrInstanceType).(string))
 		if err != nil {
 			return diag.Errorf("error retrieving instance type: %s", err)
 		}
@@ -444,6 +450,12 @@ func resourceSKSNodepoolDelete(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(err)
 	}
 
+	if d.Get(resSKSNodepoolAttrCleanup).(bool) {
+		if err := cleanupSKSNodepoolExternalResources(ctx, d, client, zone); err != nil {
+			return diag.Errorf("unable to clean up SKS Nodepool external resources: %s", err)
+		}
+	}
+
 	sksNodepoolID := d.Id()
 	if err = cluster.DeleteNodepool(ctx, &exov2.SKSNodepool{ID: &sksNodepoolID}); err != nil {
 		return diag.FromErr(err)
@@ -454,6 +466,82 @@ func resourceSKSNodepoolDelete(ctx context.Context, d *schema.ResourceData, meta
 	return nil
 }
 
+// cleanupSKSNodepoolExternalResources deletes the Network Load Balancer
+// services created by the Exoscale Cloud Controller Manager for Kubernetes
+// Services of type LoadBalancer targeting the Instance Pool of the SKS
+// Nodepool, as well as the NLBs created by the CCM left without services. It
+// must run before the Nodepool is deleted, as its Instance Pool is the only
+// link between the NLB services and the SKS cluster.
+func cleanupSKSNodepoolExternalResources(
+	ctx context.Context,
+	d *schema.ResourceData,
+	client *egoscale.Client,
+	zone string,
+) error {
+	instancePoolID := d.Get(resSKSNodepoolAttrInstancePoolID).(string)
+	if instancePoolID == "" {
+		return nil
+	}
+
+	nlbs, err := client.ListNetworkLoadBalancers(ctx, zone)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range nlbs {
+		nlb, err := client.GetNetworkLoadBalancer(ctx, zone, *n.ID)
+		if err != nil {
+			return err
+		}
+
+		services := sksNodepoolCCMServices(nlb, instancePoolID)
+		for _, service := range services {
+			log.Printf("[DEBUG] %s: deleting NLB %s service %s", resourceSKSNodepoolIDString(d), *nlb.ID, *service.ID)
+			if err := nlb.DeleteService(ctx, service); err != nil {
+				return err
+			}
+		}
+
+		if len(services) > 0 && len(services) == len(nlb.Services) && isSKSCCMNetworkLoadBalancer(nlb) {
+			log.Printf("[DEBUG] %s: deleting NLB %s", resourceSKSNodepoolIDString(d), *nlb.ID)
+			if err := client.DeleteNetworkLoadBalancer(ctx, zone, *nlb.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// sksCCMServiceNameRe matches the names of the NLB services created by the
+// Exoscale CCM, i.e. "<Kubernetes Service UID>-<port>".
+var sksCCMServiceNameRe = regexp.MustCompile(
+	`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}-[0-9]+$`)
+
+// sksNodepoolCCMServices returns the services of the NLB created by the
+// Exoscale CCM and targeting the specified Instance Pool.
+func sksNodepoolCCMServices(
+	nlb *exov2.NetworkLoadBalancer,
+	instancePoolID string,
+) []*exov2.NetworkLoadBalancerService {
+	services := make([]*exov2.NetworkLoadBalancerService, 0)
+	for _, service := range nlb.Services {
+		if defaultString(service.InstancePoolID, "") == instancePoolID &&
+			sksCCMServiceNameRe.MatchString(defaultString(service.Name, "")) {
+			services = append(services, service)
+		}
+	}
+
+	return services
+}
+
+// isSKSCCMNetworkLoadBalancer returns true if the NLB has been created by the
+// Exoscale CCM, which names them "k8s-<Kubernetes Service UID>" unless an
+// existing NLB is specified in the Kubernetes Service annotations.
+func isSKSCCMNetworkLoadBalancer(nlb *exov2.NetworkLoadBalancer) bool {
+	return strings.HasPrefix(defaultString(nlb.Name, ""), "k8s-")
+}
+
 func resourceSKSNodepoolApply(
 	ctx context.Context,
 	client *egoscale.Client,
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	exov2 "github.com/exoscale/egoscale/v2"
 	exoapi "github.com/exoscale/egoscale/v2/api"
@@ -68,6 +69,39 @@ resource "exoscale_sks_nodepool" "test" {
 		testAccResourceSKSNodepoolInstancePrefix,
 	)
 
+	testAccResourceSKSNodepoolConfigCleanup = fmt.Sprintf(`
+locals {
+  zone = "%s"
+}
+
+resource "exoscale_sks_cluster" "test" {
+  zone = local.zone
+  name = "%s"
+
+  timeouts {
+    delete = "10m"
+  }
+}
+
+resource "exoscale_sks_nodepool" "test" {
+  zone = local.zone
+  cluster_id = exoscale_sks_cluster.test.id
+  name = "%s"
+  instance_type = "%s"
+  size = 1
+  cleanup_external_resources = true
+
+  timeouts {
+    delete = "10m"
+  }
+}
+`,
+		testZoneName,
+		testAccResourceSKSClusterName,
+		testAccResourceSKSNodepoolName,
+		testAccResourceSKSNodepoolInstanceType,
+	)
+
 	testAccResourceSKSNodepoolConfigUpdate = fmt.Sprintf(`
 locals {
   zone = "%s"
@@ -250,6 +284,142 @@ func TestAccResourceSKSNodepool(t *testing.T) {
 	})
 }
 
+func TestSKSNodepoolCCMServices(t *testing.T) {
+	var (
+		instancePoolID      = "8c1f4d82-6f3b-4e35-8f4b-2c9b0b5cd2a1"
+		otherInstancePoolID = "0d2b7d4e-3c4a-4b8e-9e1f-6a0f6d8e5b72"
+		ccmNLBName          = "k8s-4f6c2a3e-1b5d-4c7e-8a9f-0b1c2d3e4f5a"
+		ccmServiceName      = "4f6c2a3e-1b5d-4c7e-8a9f-0b1c2d3e4f5a-80"
+		otherName           = "web"
+		nlb                 = &exov2.NetworkLoadBalancer{
+			Name: &ccmNLBName,
+			Services: []*exov2.NetworkLoadBalancerService{
+				{Name: &ccmServiceName, InstancePoolID: &instancePoolID},
+				{Name: &otherName, InstancePoolID: &instancePoolID},
+				{Name: &ccmServiceName, InstancePoolID: &otherInstancePoolID},
+			},
+		}
+	)
+
+	services := sksNodepoolCCMServices(nlb, instancePoolID)
+	require.Len(t, services, 1)
+	require.Equal(t, nlb.Services[0], services[0])
+	require.Empty(t, sksNodepoolCCMServices(nlb, "c3b9f2d1-0000-4000-8000-000000000000"))
+
+	require.True(t, isSKSCCMNetworkLoadBalancer(nlb))
+	require.False(t, isSKSCCMNetworkLoadBalancer(&exov2.NetworkLoadBalancer{Name: &otherName}))
+}
+
+// TestAccResourceSKSNodepoolCleanupExternalResources checks that the NLB
+// resources created by the Exoscale CCM are deleted along with the Nodepool,
+// which Terraform destroys before the SKS cluster.
+func TestAccResourceSKSNodepoolCleanupExternalResources(t *testing.T) {
+	var (
+		r           = "exoscale_sks_nodepool.test"
+		sksNodepool exov2.SKSNodepool
+		nlb         exov2.NetworkLoadBalancer
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckResourceSKSNodepoolDestroy(r),
+			testAccCheckSKSNodepoolCCMNetworkLoadBalancerDestroy(&nlb),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSKSNodepoolConfigCleanup,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceSKSNodepoolExists(r, &sksNodepool),
+					testAccCreateSKSNodepoolCCMNetworkLoadBalancer(&sksNodepool, &nlb),
+					checkResourceState(r, checkResourceStateValidateAttributes(testAttrs{
+						resSKSNodepoolAttrCleanup: validateString("true"),
+					})),
+				),
+			},
+		},
+	})
+}
+
+// testAccCreateSKSNodepoolCCMNetworkLoadBalancer creates an NLB with a service
+// targeting the Nodepool Instance Pool the way the Exoscale CCM does for a
+// Kubernetes Service of type LoadBalancer.
+func testAccCreateSKSNodepoolCCMNetworkLoadBalancer(
+	sksNodepool *exov2.SKSNodepool,
+	nlb *exov2.NetworkLoadBalancer,
+) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := GetComputeClient(testAccProvider.Meta())
+		ctx := exoapi.WithEndpoint(
+			context.Background(),
+			exoapi.NewReqEndpoint(testEnvironment, testZoneName),
+		)
+
+		// Kubernetes object UIDs are UUIDs.
+		hex := func(n int) string { return acctest.RandStringFromCharSet(n, "0123456789abcdef") }
+		uid := fmt.Sprintf("%s-%s-%s-%s-%s", hex(8), hex(4), hex(4), hex(4), hex(12))
+
+		nlbName := "k8s-" + uid
+		created, err := client.CreateNetworkLoadBalancer(ctx, testZoneName, &exov2.NetworkLoadBalancer{
+			Name: &nlbName,
+		})
+		if err != nil {
+			return err
+		}
+		*nlb = *created
+
+		var (
+			serviceName = uid + "-80"
+			port        = uint16(80)
+			targetPort  = uint16(30080)
+			protocol    = "tcp"
+			strategy    = "round-robin"
+			mode        = "tcp"
+			interval    = 10 * time.Second
+		)
+		_, err = created.AddService(ctx, &exov2.NetworkLoadBalancerService{
+			Name:           &serviceName,
+			InstancePoolID: sksNodepool.InstancePoolID,
+			Port:           &port,
+			TargetPort:     &targetPort,
+			Protocol:       &protocol,
+			Strategy:       &strategy,
+			Healthcheck: &exov2.NetworkLoadBalancerServiceHealthcheck{
+				Interval: &interval,
+				Mode:     &mode,
+				Port:     &targetPort,
+			},
+		})
+
+		return err
+	}
+}
+
+func testAccCheckSKSNodepoolCCMNetworkLoadBalancerDestroy(nlb *exov2.NetworkLoadBalancer) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if nlb.ID == nil {
+			return errors.New("NLB not created")
+		}
+
+		client := GetComputeClient(testAccProvider.Meta())
+		ctx := exoapi.WithEndpoint(
+			context.Background(),
+			exoapi.NewReqEndpoint(testEnvironment, testZoneName),
+		)
+
+		if _, err := client.GetNetworkLoadBalancer(ctx, testZoneName, *nlb.ID); err != nil {
+			if errors.Is(err, exoapi.ErrNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		return errors.New("Network Load Balancer still exists")
+	}
+}
+
 func testAccCheckResourceSKSNodepoolExists(r string, sksNodepool *exov2.SKSNodepool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[r]
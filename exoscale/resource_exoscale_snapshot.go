@@ -0,0 +1,168 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	resSnapshotAttrCreatedAt  = "created_at"
+	resSnapshotAttrInstanceID = "instance_id"
+	resSnapshotAttrName       = "name"
+	resSnapshotAttrState      = "state"
+	resSnapshotAttrZone       = "zone"
+)
+
+func resourceSnapshotIDString(d resourceIDStringer) string {
+	return resourceIDString(d, "exoscale_snapshot")
+}
+
+func resourceSnapshot() *schema.Resource {
+	s := map[string]*schema.Schema{
+		resSnapshotAttrCreatedAt: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		resSnapshotAttrInstanceID: {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		resSnapshotAttrName: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		resSnapshotAttrState: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		resSnapshotAttrZone: {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+	}
+
+	return &schema.Resource{
+		Schema: s,
+
+		CreateContext: resourceSnapshotCreate,
+		ReadContext:   resourceSnapshotRead,
+		DeleteContext: resourceSnapshotDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: zonedStateContextFunc,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+	}
+}
+
+func resourceSnapshotCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning create", resourceSnapshotIDString(d))
+
+	zone := d.Get(resSnapshotAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	instance, err := client.GetInstance(ctx, zone, d.Get(resSnapshotAttrInstanceID).(string))
+	if err != nil {
+		return diag.Errorf("unable to retrieve Compute instance: %s", err)
+	}
+
+	snapshot, err := instance.CreateSnapshot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(*snapshot.ID)
+
+	log.Printf("[DEBUG] %s: create finished successfully", resourceSnapshotIDString(d))
+
+	return resourceSnapshotRead(ctx, d, meta)
+}
+
+func resourceSnapshotRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning read", resourceSnapshotIDString(d))
+
+	zone := d.Get(resSnapshotAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	snapshot, err := client.GetSnapshot(ctx, zone, d.Id())
+	if err != nil {
+		if errors.Is(err, exoapi.ErrNotFound) {
+			// Resource doesn't exist anymore, signaling the core to remove it from the state.
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: read finished successfully", resourceSnapshotIDString(d))
+
+	return resourceSnapshotApply(ctx, d, snapshot)
+}
+
+func resourceSnapshotDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning delete", resourceSnapshotIDString(d))
+
+	zone := d.Get(resSnapshotAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutDelete))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	if err := client.DeleteSnapshot(ctx, zone, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: delete finished successfully", resourceSnapshotIDString(d))
+
+	return nil
+}
+
+func resourceSnapshotApply(_ context.Context, d *schema.ResourceData, snapshot *exov2.Snapshot) diag.Diagnostics {
+	var createdAt string
+	if snapshot.CreatedAt != nil {
+		createdAt = snapshot.CreatedAt.String()
+	}
+
+	if err := d.Set(resSnapshotAttrCreatedAt, createdAt); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resSnapshotAttrInstanceID, defaultString(snapshot.InstanceID, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resSnapshotAttrName, defaultString(snapshot.Name, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resSnapshotAttrState, defaultString(snapshot.State, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
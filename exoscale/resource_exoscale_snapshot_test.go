@@ -0,0 +1,149 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAccResourceSnapshotInstanceName = acctest.RandomWithPrefix(testPrefix)
+
+	testAccResourceSnapshotConfig = fmt.Sprintf(`
+locals {
+  zone = "%s"
+}
+
+resource "exoscale_compute_instance" "test" {
+  zone = local.zone
+  name = "%s"
+  type = "standard.tiny"
+  template_id = "%s"
+  disk_size = 10
+
+  timeouts {
+    delete = "10m"
+  }
+}
+
+resource "exoscale_snapshot" "test" {
+  zone = local.zone
+  instance_id = exoscale_compute_instance.test.id
+}
+`,
+		testZoneName,
+		testAccResourceSnapshotInstanceName,
+		testInstanceTemplateID,
+	)
+)
+
+func TestAccResourceSnapshot(t *testing.T) {
+	var (
+		r        = "exoscale_snapshot.test"
+		snapshot exov2.Snapshot
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckResourceSnapshotDestroy(&snapshot),
+		Steps: []resource.TestStep{
+			{
+				// Create
+				Config: testAccResourceSnapshotConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceSnapshotExists(r, &snapshot),
+					func(s *terraform.State) error {
+						a := require.New(t)
+
+						a.NotNil(snapshot.InstanceID)
+						a.NotNil(snapshot.Name)
+
+						return nil
+					},
+					checkResourceState(r, checkResourceStateValidateAttributes(testAttrs{
+						resSnapshotAttrCreatedAt:  validation.ToDiagFunc(validation.NoZeroValues),
+						resSnapshotAttrInstanceID: validation.ToDiagFunc(validation.IsUUID),
+						resSnapshotAttrName:       validation.ToDiagFunc(validation.NoZeroValues),
+						resSnapshotAttrState:      validation.ToDiagFunc(validation.NoZeroValues),
+					})),
+				),
+			},
+			{
+				// Import
+				ResourceName: r,
+				ImportStateIdFunc: func(snapshot *exov2.Snapshot) resource.ImportStateIdFunc {
+					return func(*terraform.State) (string, error) {
+						return fmt.Sprintf("%s@%s", *snapshot.ID, testZoneName), nil
+					}
+				}(&snapshot),
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateCheck: func(s []*terraform.InstanceState) error {
+					return checkResourceAttributes(
+						testAttrs{
+							resSnapshotAttrInstanceID: validateString(*snapshot.InstanceID),
+						},
+						s[0].Attributes)
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckResourceSnapshotExists(r string, snapshot *exov2.Snapshot) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[r]
+		if !ok {
+			return errors.New("resource not found in the state")
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("resource ID not set")
+		}
+
+		client := GetComputeClient(testAccProvider.Meta())
+		ctx := exoapi.WithEndpoint(
+			context.Background(),
+			exoapi.NewReqEndpoint(testEnvironment, testZoneName),
+		)
+
+		res, err := client.GetSnapshot(ctx, testZoneName, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*snapshot = *res
+		return nil
+	}
+}
+
+func testAccCheckResourceSnapshotDestroy(snapshot *exov2.Snapshot) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		client := GetComputeClient(testAccProvider.Meta())
+		ctx := exoapi.WithEndpoint(
+			context.Background(),
+			exoapi.NewReqEndpoint(testEnvironment, testZoneName),
+		)
+
+		_, err := client.GetSnapshot(ctx, testZoneName, *snapshot.ID)
+		if err != nil {
+			if errors.Is(err, exoapi.ErrNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		return errors.New("snapshot still exists")
+	}
+}
@@ -0,0 +1,219 @@
+package exoscale
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	resSSHKeyAttrFingerprint           = "fingerprint"
+	resSSHKeyAttrName                  = "name"
+	resSSHKeyAttrNameCollisionStrategy = "name_collision_strategy"
+	resSSHKeyAttrPublicKey             = "public_key"
+)
+
+func resourceSSHKeyIDString(d resourceIDStringer) string {
+	return resourceIDString(d, "exoscale_ssh_key")
+}
+
+func resourceSSHKey() *schema.Resource {
+	s := map[string]*schema.Schema{
+		resSSHKeyAttrFingerprint: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		resSSHKeyAttrName: {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			DiffSuppressFunc: suppressNameCollisionSuffix,
+		},
+		resSSHKeyAttrNameCollisionStrategy: nameCollisionStrategySchema(),
+		resSSHKeyAttrPublicKey: {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateFunc:     validation.StringIsNotWhiteSpace,
+			DiffSuppressFunc: suppressImportedSSHKeyPublicKey,
+		},
+	}
+
+	return &schema.Resource{
+		Schema: s,
+
+		CreateContext: resourceSSHKeyCreate,
+		ReadContext:   resourceSSHKeyRead,
+		UpdateContext: resourceSSHKeyUpdate,
+		DeleteContext: resourceSSHKeyDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSSHKeyImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+	}
+}
+
+// suppressImportedSSHKeyPublicKey is a DiffSuppressFunc for the public_key
+// attribute: the API doesn't return the public key of existing SSH keys, so
+// the attribute is empty in the state after an import. In this case, the diff
+// is suppressed if the configured public key matches the fingerprint of the
+// SSH key instead of replacing it.
+func suppressImportedSSHKeyPublicKey(_, old, new string, d *schema.ResourceData) bool {
+	if old != "" {
+		return false
+	}
+
+	fingerprint, err := sshKeyFingerprint(new)
+	if err != nil {
+		return false
+	}
+
+	return fingerprint == d.Get(resSSHKeyAttrFingerprint).(string)
+}
+
+// sshKeyFingerprint returns the MD5 fingerprint of an OpenSSH public key
+// (e.g. "ssh-ed25519 AAAA... comment"), in the format reported by the API.
+func sshKeyFingerprint(publicKey string) (string, error) {
+	fields := strings.Fields(publicKey)
+	if len(fields) < 2 {
+		return "", errors.New("invalid public key format")
+	}
+
+	key, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid public key: %w", err)
+	}
+
+	sum := md5.Sum(key) // nolint: gosec
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02x", b)
+	}
+
+	return strings.Join(parts, ":"), nil
+}
+
+func resourceSSHKeyImport(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{},
+) ([]*schema.ResourceData, error) {
+	// The name collision strategy only applies on creation, setting the
+	// default value prevents a spurious diff on the first plan after import.
+	if err := d.Set(resSSHKeyAttrNameCollisionStrategy, nameCollisionStrategyFail); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceSSHKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning create", resourceSSHKeyIDString(d))
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), defaultZone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	err := createWithNameCollisionStrategy(
+		d,
+		"exoscale_ssh_key",
+		func(name string) (string, error) {
+			if _, err := client.GetSSHKey(ctx, defaultZone, name); err != nil {
+				if errors.Is(err, exoapi.ErrNotFound) {
+					return "", nil
+				}
+				return "", err
+			}
+			return name, nil
+		},
+		func(name string) error {
+			sshKey, err := client.RegisterSSHKey(ctx, defaultZone, name, d.Get(resSSHKeyAttrPublicKey).(string))
+			if err != nil {
+				return err
+			}
+
+			d.SetId(*sshKey.Name)
+			return nil
+		},
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: create finished successfully", resourceSSHKeyIDString(d))
+
+	return resourceSSHKeyRead(ctx, d, meta)
+}
+
+func resourceSSHKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning read", resourceSSHKeyIDString(d))
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), defaultZone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	sshKey, err := client.GetSSHKey(ctx, defaultZone, d.Id())
+	if err != nil {
+		if errors.Is(err, exoapi.ErrNotFound) {
+			// Resource doesn't exist anymore, signaling the core to remove it from the state.
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resSSHKeyAttrFingerprint, defaultString(sshKey.Fingerprint, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resSSHKeyAttrName, defaultString(sshKey.Name, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: read finished successfully", resourceSSHKeyIDString(d))
+
+	return nil
+}
+
+// resourceSSHKeyUpdate only handles changes of the name collision strategy,
+// which doesn't apply to existing resources.
+func resourceSSHKeyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceSSHKeyRead(ctx, d, meta)
+}
+
+func resourceSSHKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning delete", resourceSSHKeyIDString(d))
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutDelete))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), defaultZone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	if err := client.DeleteSSHKey(ctx, defaultZone, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: delete finished successfully", resourceSSHKeyIDString(d))
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAccResourceSSHKeyName = acctest.RandomWithPrefix(testPrefix)
+
+	testAccResourceSSHKeyConfig = fmt.Sprintf(`
+resource "exoscale_ssh_key" "test" {
+  name       = "%s"
+  public_key = "%s"
+}
+`,
+		testAccResourceSSHKeyName,
+		testAccResourceSSHKey2,
+	)
+)
+
+func TestAccResourceSSHKey(t *testing.T) {
+	var (
+		r      = "exoscale_ssh_key.test"
+		sshKey exov2.SSHKey
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckResourceSSHKeyDestroy(&sshKey),
+		Steps: []resource.TestStep{
+			{
+				// Create
+				Config: testAccResourceSSHKeyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceSSHKeyExists(r, &sshKey),
+					func(s *terraform.State) error {
+						a := require.New(t)
+
+						a.Equal(testAccResourceSSHKeyName, *sshKey.Name)
+						a.Equal(testAccResourceSSHKeyFingerprint2, *sshKey.Fingerprint)
+
+						return nil
+					},
+					checkResourceState(r, checkResourceStateValidateAttributes(testAttrs{
+						resSSHKeyAttrFingerprint: validateString(testAccResourceSSHKeyFingerprint2),
+						resSSHKeyAttrName:        validateString(testAccResourceSSHKeyName),
+						resSSHKeyAttrPublicKey:   validateString(testAccResourceSSHKey2),
+					})),
+				),
+			},
+			{
+				// Import
+				ResourceName: r,
+				ImportState:  true,
+				// The API doesn't return the public key of existing SSH keys, so
+				// instead of verifying the imported state against the previous
+				// one, check that planning the configuration against it yields
+				// no changes.
+				ImportStateCheck: func(s []*terraform.InstanceState) error {
+					if err := checkResourceAttributes(
+						testAttrs{
+							resSSHKeyAttrFingerprint:           validateString(testAccResourceSSHKeyFingerprint2),
+							resSSHKeyAttrName:                  validateString(testAccResourceSSHKeyName),
+							resSSHKeyAttrNameCollisionStrategy: validateString(nameCollisionStrategyFail),
+						},
+						s[0].Attributes); err != nil {
+						return err
+					}
+
+					return testCheckResourceSSHKeyImportDiff(s[0], testAccResourceSSHKeyName, testAccResourceSSHKey2)
+				},
+			},
+		},
+	})
+}
+
+func TestSSHKeyFingerprint(t *testing.T) {
+	fingerprint, err := sshKeyFingerprint(testAccResourceSSHKey2)
+	require.NoError(t, err)
+	require.Equal(t, testAccResourceSSHKeyFingerprint2, fingerprint)
+
+	_, err = sshKeyFingerprint("ssh-rsa")
+	require.Error(t, err)
+
+	_, err = sshKeyFingerprint("ssh-rsa not-base64!")
+	require.Error(t, err)
+}
+
+func TestResourceSSHKeyImportDiff(t *testing.T) {
+	imported := &terraform.InstanceState{
+		ID: testAccResourceSSHKeyName,
+		Attributes: map[string]string{
+			"id":                               testAccResourceSSHKeyName,
+			resSSHKeyAttrFingerprint:           testAccResourceSSHKeyFingerprint2,
+			resSSHKeyAttrName:                  testAccResourceSSHKeyName,
+			resSSHKeyAttrNameCollisionStrategy: nameCollisionStrategyFail,
+		},
+	}
+
+	require.NoError(t, testCheckResourceSSHKeyImportDiff(imported, testAccResourceSSHKeyName, testAccResourceSSHKey2))
+	require.Error(t, testCheckResourceSSHKeyImportDiff(
+		imported,
+		testAccResourceSSHKeyName,
+		"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAABAgMEBQYHCAkKCwwNDg8QERITFBUWFxgZGhscHR4f",
+	))
+}
+
+// testCheckResourceSSHKeyImportDiff checks that planning an exoscale_ssh_key
+// resource configured with the specified name and public key against the
+// imported state yields no changes.
+func testCheckResourceSSHKeyImportDiff(imported *terraform.InstanceState, name, publicKey string) error {
+	diff, err := resourceSSHKey().Diff(
+		context.Background(),
+		imported,
+		terraform.NewResourceConfigRaw(map[string]interface{}{
+			resSSHKeyAttrName:      name,
+			resSSHKeyAttrPublicKey: publicKey,
+		}),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	if diff != nil && !diff.Empty() {
+		return fmt.Errorf("expected an empty plan after import, got: %#v", diff.Attributes)
+	}
+
+	return nil
+}
+
+func testAccCheckResourceSSHKeyExists(r string, sshKey *exov2.SSHKey) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[r]
+		if !ok {
+			return errors.New("resource not found in the state")
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("resource ID not set")
+		}
+
+		client := GetComputeClient(testAccProvider.Meta())
+		ctx := exoapi.WithEndpoint(
+			context.Background(),
+			exoapi.NewReqEndpoint(testEnvironment, defaultZone),
+		)
+
+		res, err := client.Client.GetSSHKey(ctx, defaultZone, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*sshKey = *res
+		return nil
+	}
+}
+
+func testAccCheckResourceSSHKeyDestroy(sshKey *exov2.SSHKey) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		client := GetComputeClient(testAccProvider.Meta())
+		ctx := exoapi.WithEndpoint(
+			context.Background(),
+			exoapi.NewReqEndpoint(testEnvironment, defaultZone),
+		)
+
+		_, err := client.GetSSHKey(ctx, defaultZone, *sshKey.Name)
+		if err != nil {
+			if errors.Is(err, exoapi.ErrNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		return errors.New("SSH key still exists")
+	}
+}
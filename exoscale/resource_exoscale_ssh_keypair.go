@@ -64,6 +64,13 @@ func resourceSSHKeypairCreate(d *schema.ResourceData, meta interface{}) error {
 	client := GetComputeClient(meta)
 
 	name := d.Get("name").(string)
+
+	if _, err := client.GetWithContext(ctx, &egoscale.SSHKeyPair{Name: name}); err == nil {
+		return nameConflictError("exoscale_ssh_keypair", name, name)
+	} else if !isNotFoundError(err) {
+		return err
+	}
+
 	publicKey, publicKeyOk := d.GetOk("public_key")
 	if publicKeyOk {
 		resp, err := client.RequestWithContext(ctx, &egoscale.RegisterSSHKeyPair{
@@ -0,0 +1,262 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	resTemplateAttrBootMode        = "boot_mode"
+	resTemplateAttrChecksum        = "checksum"
+	resTemplateAttrDefaultUser     = "default_user"
+	resTemplateAttrDescription     = "description"
+	resTemplateAttrName            = "name"
+	resTemplateAttrPasswordEnabled = "password_enabled"
+	resTemplateAttrSSHKeyEnabled   = "ssh_key_enabled"
+	resTemplateAttrSize            = "size"
+	resTemplateAttrURL             = "url"
+	resTemplateAttrVisibility      = "visibility"
+	resTemplateAttrZone            = "zone"
+)
+
+func resourceTemplateIDString(d resourceIDStringer) string {
+	return resourceIDString(d, "exoscale_template")
+}
+
+func resourceTemplate() *schema.Resource {
+	s := map[string]*schema.Schema{
+		resTemplateAttrBootMode: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{"legacy", "uefi"}, false),
+		},
+		resTemplateAttrChecksum: {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		resTemplateAttrDefaultUser: {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+		},
+		resTemplateAttrDescription: {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+		},
+		resTemplateAttrName: {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		resTemplateAttrPasswordEnabled: {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+			ForceNew: true,
+		},
+		resTemplateAttrSSHKeyEnabled: {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+			ForceNew: true,
+		},
+		resTemplateAttrSize: {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		resTemplateAttrURL: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
+		},
+		resTemplateAttrVisibility: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		resTemplateAttrZone: {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+	}
+
+	return &schema.Resource{
+		Schema: s,
+
+		CreateContext: resourceTemplateCreate,
+		ReadContext:   resourceTemplateRead,
+		DeleteContext: resourceTemplateDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: zonedStateContextFunc,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+	}
+}
+
+func resourceTemplateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning create", resourceTemplateIDString(d))
+
+	zone := d.Get(resTemplateAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	var (
+		checksum        = d.Get(resTemplateAttrChecksum).(string)
+		name            = d.Get(resTemplateAttrName).(string)
+		passwordEnabled = d.Get(resTemplateAttrPasswordEnabled).(bool)
+		sshKeyEnabled   = d.Get(resTemplateAttrSSHKeyEnabled).(bool)
+		url             = d.Get(resTemplateAttrURL).(string)
+	)
+
+	template := &exov2.Template{
+		Checksum:        &checksum,
+		Name:            &name,
+		PasswordEnabled: &passwordEnabled,
+		SSHKeyEnabled:   &sshKeyEnabled,
+		URL:             &url,
+	}
+
+	if v, ok := d.GetOk(resTemplateAttrBootMode); ok {
+		s := v.(string)
+		template.BootMode = &s
+	}
+
+	if v, ok := d.GetOk(resTemplateAttrDefaultUser); ok {
+		s := v.(string)
+		template.DefaultUser = &s
+	}
+
+	if v, ok := d.GetOk(resTemplateAttrDescription); ok {
+		s := v.(string)
+		template.Description = &s
+	}
+
+	template, err := client.RegisterTemplate(ctx, zone, template)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(*template.ID)
+
+	log.Printf("[DEBUG] %s: create finished successfully", resourceTemplateIDString(d))
+
+	return resourceTemplateRead(ctx, d, meta)
+}
+
+func resourceTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning read", resourceTemplateIDString(d))
+
+	zone := d.Get(resTemplateAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	template, err := client.GetTemplate(ctx, zone, d.Id())
+	if err != nil {
+		if errors.Is(err, exoapi.ErrNotFound) {
+			// Resource doesn't exist anymore, signaling the core to remove it from the state.
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: read finished successfully", resourceTemplateIDString(d))
+
+	return resourceTemplateApply(ctx, d, template)
+}
+
+func resourceTemplateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] %s: beginning delete", resourceTemplateIDString(d))
+
+	zone := d.Get(resTemplateAttrZone).(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutDelete))
+	ctx = exoapi.WithEndpoint(ctx, exoapi.NewReqEndpoint(getEnvironment(meta), zone))
+	defer cancel()
+
+	client := GetComputeClient(meta)
+
+	if err := client.DeleteTemplate(ctx, zone, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s: delete finished successfully", resourceTemplateIDString(d))
+
+	return nil
+}
+
+func resourceTemplateApply(_ context.Context, d *schema.ResourceData, template *exov2.Template) diag.Diagnostics {
+	if err := d.Set(resTemplateAttrBootMode, defaultString(template.BootMode, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The template source URL and checksum are not always returned by the
+	// API, in which case the values from the configuration are kept.
+	if template.Checksum != nil {
+		if err := d.Set(resTemplateAttrChecksum, *template.Checksum); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := d.Set(resTemplateAttrDefaultUser, defaultString(template.DefaultUser, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resTemplateAttrDescription, defaultString(template.Description, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resTemplateAttrName, defaultString(template.Name, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resTemplateAttrPasswordEnabled, defaultBool(template.PasswordEnabled, false)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resTemplateAttrSSHKeyEnabled, defaultBool(template.SSHKeyEnabled, false)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(resTemplateAttrSize, defaultInt64(template.Size, 0)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if template.URL != nil {
+		if err := d.Set(resTemplateAttrURL, *template.URL); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := d.Set(resTemplateAttrVisibility, defaultString(template.Visibility, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package exoscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	exov2 "github.com/exoscale/egoscale/v2"
+	exoapi "github.com/exoscale/egoscale/v2/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAccResourceTemplateName        = acctest.RandomWithPrefix(testPrefix)
+	testAccResourceTemplateDescription = acctest.RandString(10)
+	testAccResourceTemplateDefaultUser = "ubuntu"
+
+	// The disk image to register the test template from (QCOW2 format) and
+	// its MD5 checksum are provided by the environment, as it must be
+	// reachable from the Exoscale API.
+	testAccResourceTemplateURL      = os.Getenv("EXOSCALE_TEST_TEMPLATE_URL")
+	testAccResourceTemplateChecksum = os.Getenv("EXOSCALE_TEST_TEMPLATE_CHECKSUM")
+
+	testAccResourceTemplateConfig = fmt.Sprintf(`
+resource "exoscale_template" "test" {
+  zone = "%s"
+  name = "%s"
+  description = "%s"
+  url = "%s"
+  checksum = "%s"
+  boot_mode = "uefi"
+  default_user = "%s"
+  password_enabled = false
+}
+`,
+		testZoneName,
+		testAccResourceTemplateName,
+		testAccResourceTemplateDescription,
+		testAccResourceTemplateURL,
+		testAccResourceTemplateChecksum,
+		testAccResourceTemplateDefaultUser,
+	)
+)
+
+func TestAccResourceTemplate(t *testing.T) {
+	if testAccResourceTemplateURL == "" || testAccResourceTemplateChecksum == "" {
+		t.Skip("EXOSCALE_TEST_TEMPLATE_URL and EXOSCALE_TEST_TEMPLATE_CHECKSUM must be set")
+	}
+
+	var (
+		r        = "exoscale_template.test"
+		template exov2.Template
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckResourceTemplateDestroy(&template),
+		Steps: []resource.TestStep{
+			{
+				// Create
+				Config: testAccResourceTemplateConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceTemplateExists(r, &template),
+					func(s *terraform.State) error {
+						a := require.New(t)
+
+						a.Equal("uefi", *template.BootMode)
+						a.Equal(testAccResourceTemplateDefaultUser, *template.DefaultUser)
+						a.Equal(testAccResourceTemplateDescription, *template.Description)
+						a.Equal(testAccResourceTemplateName, *template.Name)
+						a.False(*template.PasswordEnabled)
+						a.True(*template.SSHKeyEnabled)
+
+						return nil
+					},
+					checkResourceState(r, checkResourceStateValidateAttributes(testAttrs{
+						resTemplateAttrBootMode:        validateString("uefi"),
+						resTemplateAttrChecksum:        validateString(testAccResourceTemplateChecksum),
+						resTemplateAttrDefaultUser:     validateString(testAccResourceTemplateDefaultUser),
+						resTemplateAttrDescription:     validateString(testAccResourceTemplateDescription),
+						resTemplateAttrName:            validateString(testAccResourceTemplateName),
+						resTemplateAttrPasswordEnabled: validateString("false"),
+						resTemplateAttrSSHKeyEnabled:   validateString("true"),
+						resTemplateAttrURL:             validateString(testAccResourceTemplateURL),
+						resTemplateAttrVisibility:      validateString("private"),
+					})),
+				),
+			},
+			{
+				// Import
+				ResourceName: r,
+				ImportStateIdFunc: func(template *exov2.Template) resource.ImportStateIdFunc {
+					return func(*terraform.State) (string, error) {
+						return fmt.Sprintf("%s@%s", *template.ID, testZoneName), nil
+					}
+				}(&template),
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{resTemplateAttrChecksum, resTemplateAttrURL},
+				ImportStateCheck: func(s []*terraform.InstanceState) error {
+					return checkResourceAttributes(
+						testAttrs{
+							resTemplateAttrBootMode:    validateString("uefi"),
+							resTemplateAttrDefaultUser: validateString(testAccResourceTemplateDefaultUser),
+							resTemplateAttrName:        validateString(testAccResourceTemplateName),
+						},
+						s[0].Attributes)
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckResourceTemplateExists(r string, template *exov2.Template) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[r]
+		if !ok {
+			return errors.New("resource not found in the state")
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("resource ID not set")
+		}
+
+		client := GetComputeClient(testAccProvider.Meta())
+		ctx := exoapi.WithEndpoint(
+			context.Background(),
+			exoapi.NewReqEndpoint(testEnvironment, testZoneName),
+		)
+
+		res, err := client.Client.GetTemplate(ctx, testZoneName, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*template = *res
+		return nil
+	}
+}
+
+func testAccCheckResourceTemplateDestroy(template *exov2.Template) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		client := GetComputeClient(testAccProvider.Meta())
+		ctx := exoapi.WithEndpoint(
+			context.Background(),
+			exoapi.NewReqEndpoint(testEnvironment, testZoneName),
+		)
+
+		_, err := client.GetTemplate(ctx, testZoneName, *template.ID)
+		if err != nil {
+			if errors.Is(err, exoapi.ErrNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		return errors.New("template still exists")
+	}
+}
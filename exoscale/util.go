@@ -1,5 +1,17 @@
 package exoscale
 
+import (
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+)
+
+// waitProgressReportInterval is the interval at which waitProgress reports the
+// time elapsed waiting for an operation in the absence of state transitions.
+const waitProgressReportInterval = time.Minute
+
 // in returns true if v is found in list.
 func in(list []string, v string) bool {
 	for i := range list {
@@ -11,6 +23,39 @@ func in(list []string, v string) bool {
 	return false
 }
 
+// findByLabels returns the index of the only one of n resources matching all
+// the specified labels, resourceLabels returning the labels of the i-th resource
+// (nil if it has none). kind is the resource kind used in error messages.
+func findByLabels(
+	kind string,
+	labels map[string]interface{},
+	n int,
+	resourceLabels func(i int) *map[string]string,
+) (int, error) {
+	filter := make(map[string]string, len(labels))
+	for k, v := range labels {
+		filter[k] = v.(string)
+	}
+
+	found := -1
+	for i := 0; i < n; i++ {
+		if l := resourceLabels(i); l == nil || !labelsMatch(*l, filter) {
+			continue
+		}
+
+		if found >= 0 {
+			return -1, fmt.Errorf("more than one %s matches the specified labels", kind)
+		}
+		found = i
+	}
+
+	if found < 0 {
+		return -1, fmt.Errorf("no %s matches the specified labels", kind)
+	}
+
+	return found, nil
+}
+
 // defaultString returns the value of the string pointer v if not nil, otherwise the default value specified.
 func defaultString(v *string, def string) string {
 	if v != nil {
@@ -37,3 +82,97 @@ func defaultBool(v *bool, def bool) bool {
 
 	return def
 }
+
+// normalizeCIDR returns the network CIDR notation of v, which can be either a
+// CIDR or a single IP address (e.g. "1.2.3.4" -> "1.2.3.4/32").
+func normalizeCIDR(v string) (string, error) {
+	if !strings.Contains(v, "/") {
+		ip := net.ParseIP(v)
+		if ip == nil {
+			return "", fmt.Errorf("invalid IP address %q", v)
+		}
+
+		if ip.To4() != nil {
+			return ip.String() + "/32", nil
+		}
+		return ip.String() + "/128", nil
+	}
+
+	_, ipNet, err := net.ParseCIDR(v)
+	if err != nil {
+		return "", fmt.Errorf("invalid CIDR %q", v)
+	}
+
+	return ipNet.String(), nil
+}
+
+// waitProgress reports the progress of a long-running operation waited for
+// using a resource.StateChangeConf, so that long applies aren't silent: state
+// transitions are logged as soon as they are observed, and the time elapsed
+// waiting (relative to the timeout) is logged periodically otherwise.
+type waitProgress struct {
+	prefix     string
+	operation  string
+	timeout    time.Duration
+	start      time.Time
+	lastState  string
+	lastReport time.Time
+}
+
+// newWaitProgress returns a waitProgress for the specified operation, prefix
+// being the ID string of the resource being waited for.
+func newWaitProgress(prefix, operation string, timeout time.Duration) *waitProgress {
+	now := time.Now()
+
+	return &waitProgress{
+		prefix:     prefix,
+		operation:  operation,
+		timeout:    timeout,
+		start:      now,
+		lastReport: now,
+	}
+}
+
+// report logs at INFO level the observed state of the operation if there is
+// anything to report. It is meant to be called from StateChangeConf Refresh
+// functions.
+func (p *waitProgress) report(state string) {
+	if msg := p.message(state, time.Now()); msg != "" {
+		log.Printf("[INFO] %s", msg)
+	}
+}
+
+// message returns the progress message to report for the observed state at
+// the specified time, or an empty string if there is nothing to report.
+func (p *waitProgress) message(state string, now time.Time) string {
+	if state == p.lastState && now.Sub(p.lastReport) < waitProgressReportInterval {
+		return ""
+	}
+
+	elapsed := now.Sub(p.start).Round(time.Second)
+
+	var msg string
+	if state != p.lastState {
+		if p.lastState == "" {
+			msg = fmt.Sprintf("%s: %s: state %s", p.prefix, p.operation, state)
+		} else {
+			msg = fmt.Sprintf("%s: %s: state %s -> %s", p.prefix, p.operation, p.lastState, state)
+		}
+	} else {
+		msg = fmt.Sprintf("%s: %s: still %s", p.prefix, p.operation, state)
+	}
+
+	if p.timeout > 0 {
+		msg += fmt.Sprintf(" (%s elapsed, %d%% of the %s timeout)",
+			elapsed,
+			int(100*elapsed/p.timeout),
+			p.timeout)
+	} else {
+		msg += fmt.Sprintf(" (%s elapsed)", elapsed)
+	}
+
+	p.lastState = state
+	p.lastReport = now
+
+	return msg
+}
@@ -1,6 +1,9 @@
 package exoscale
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func Test_in(t *testing.T) {
 	type args struct {
@@ -59,6 +62,57 @@ func Test_in(t *testing.T) {
 	}
 }
 
+func Test_findByLabels(t *testing.T) {
+	resources := []*map[string]string{
+		nil,
+		{"app": "web", "env": "prod"},
+		{"app": "web", "env": "dev"},
+		{"app": "db", "env": "prod"},
+	}
+	resourceLabels := func(i int) *map[string]string { return resources[i] }
+
+	tests := []struct {
+		name    string
+		labels  map[string]interface{}
+		want    int
+		wantErr string
+	}{
+		{
+			name:   "single match",
+			labels: map[string]interface{}{"app": "web", "env": "dev"},
+			want:   2,
+		},
+		{
+			name:    "several matches",
+			labels:  map[string]interface{}{"app": "web"},
+			wantErr: "more than one Instance Pool matches the specified labels",
+		},
+		{
+			name:    "no match",
+			labels:  map[string]interface{}{"app": "cache"},
+			wantErr: "no Instance Pool matches the specified labels",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findByLabels("Instance Pool", tt.labels, len(resources), resourceLabels)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("findByLabels() error = %v, wantErr %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("findByLabels() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("findByLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_defaultString(t *testing.T) {
 	type args struct {
 		v   *string
@@ -187,3 +241,108 @@ func Test_defaultBool(t *testing.T) {
 		})
 	}
 }
+
+func Test_normalizeCIDR(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "IPv4 address",
+			v:    "1.2.3.4",
+			want: "1.2.3.4/32",
+		},
+		{
+			name: "IPv4 CIDR",
+			v:    "1.2.3.4/32",
+			want: "1.2.3.4/32",
+		},
+		{
+			name: "IPv4 CIDR with host bits",
+			v:    "1.2.3.4/24",
+			want: "1.2.3.0/24",
+		},
+		{
+			name: "IPv6 address",
+			v:    "2001:db8::1",
+			want: "2001:db8::1/128",
+		},
+		{
+			name: "IPv6 CIDR",
+			v:    "2001:db8::/64",
+			want: "2001:db8::/64",
+		},
+		{
+			name:    "invalid address",
+			v:       "lolnope",
+			wantErr: true,
+		},
+		{
+			name:    "invalid CIDR",
+			v:       "1.2.3.4/42",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeCIDR(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("normalizeCIDR() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("normalizeCIDR() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_waitProgress_message(t *testing.T) {
+	p := newWaitProgress("test", "waiting", 10*time.Minute)
+	start := p.start
+
+	tests := []struct {
+		name  string
+		state string
+		at    time.Duration
+		want  string
+	}{
+		{
+			name:  "initial state",
+			state: "rebuilding",
+			at:    0,
+			want:  "test: waiting: state rebuilding (0s elapsed, 0% of the 10m0s timeout)",
+		},
+		{
+			name:  "same state before report interval",
+			state: "rebuilding",
+			at:    30 * time.Second,
+			want:  "",
+		},
+		{
+			name:  "same state after report interval",
+			state: "rebuilding",
+			at:    time.Minute,
+			want:  "test: waiting: still rebuilding (1m0s elapsed, 10% of the 10m0s timeout)",
+		},
+		{
+			name:  "state transition",
+			state: "running",
+			at:    90 * time.Second,
+			want:  "test: waiting: state rebuilding -> running (1m30s elapsed, 15% of the 10m0s timeout)",
+		},
+	}
+
+	// Test cases are run sequentially against the same waitProgress, as
+	// the reported message depends on the previously observed state.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.message(tt.state, start.Add(tt.at)); got != tt.want {
+				t.Errorf("waitProgress.message() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package exoscale
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -76,3 +78,135 @@ func validateComputeInstanceType(v interface{}, _ cty.Path) diag.Diagnostics {
 
 	return nil
 }
+
+// validateJSONSchema performs a best-effort validation of the decoded JSON
+// value v against the JSON schema s, checking value types, enums, numeric
+// bounds and unknown object properties. The path argument is used as prefix
+// of the returned error messages.
+func validateJSONSchema(s map[string]interface{}, v interface{}, path string) error {
+	if types := jsonSchemaTypes(s); len(types) > 0 {
+		var ok bool
+		for _, t := range types {
+			if jsonValueIsType(v, t) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return fmt.Errorf("%s: expected type %s, got %s",
+				path, strings.Join(types, " or "), jsonValueType(v))
+		}
+	}
+
+	if enum, ok := s["enum"].([]interface{}); ok && len(enum) > 0 {
+		var ok bool
+		for _, e := range enum {
+			if fmt.Sprint(e) == fmt.Sprint(v) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return fmt.Errorf("%s: value %v is not one of %v", path, v, enum)
+		}
+	}
+
+	switch value := v.(type) {
+	case float64:
+		if minimum, ok := s["minimum"].(float64); ok && value < minimum {
+			return fmt.Errorf("%s: value %v is lower than minimum %v", path, value, minimum)
+		}
+		if maximum, ok := s["maximum"].(float64); ok && value > maximum {
+			return fmt.Errorf("%s: value %v is greater than maximum %v", path, value, maximum)
+		}
+
+	case []interface{}:
+		if items, ok := s["items"].(map[string]interface{}); ok {
+			for i, item := range value {
+				if err := validateJSONSchema(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+
+	case map[string]interface{}:
+		properties, _ := s["properties"].(map[string]interface{})
+
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			propertySchema, ok := properties[k].(map[string]interface{})
+			if !ok {
+				if additional, ok := s["additionalProperties"].(bool); ok && !additional {
+					return fmt.Errorf("%s: unsupported property %q", path, k)
+				}
+				continue
+			}
+
+			if err := validateJSONSchema(propertySchema, value[k], path+"."+k); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// jsonSchemaTypes returns the list of types allowed by the JSON schema s.
+func jsonSchemaTypes(s map[string]interface{}) []string {
+	switch t := s["type"].(type) {
+	case string:
+		return []string{t}
+
+	case []interface{}:
+		types := make([]string, 0, len(t))
+		for _, v := range t {
+			if s, ok := v.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+
+	return nil
+}
+
+// jsonValueIsType returns true if the decoded JSON value v is of JSON schema type t.
+func jsonValueIsType(v interface{}, t string) bool {
+	switch t {
+	case "integer":
+		f, ok := v.(float64)
+		return ok && f == math.Trunc(f)
+
+	case "number":
+		_, ok := v.(float64)
+		return ok
+
+	default:
+		return jsonValueType(v) == t
+	}
+}
+
+// jsonValueType returns the JSON schema type name of the decoded JSON value v.
+func jsonValueType(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
@@ -1,6 +1,7 @@
 package exoscale
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/hashicorp/go-cty/cty"
@@ -149,3 +150,93 @@ func Test_validateComputeInstanceType(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateJSONSchema(t *testing.T) {
+	testSchema := `{
+  "type": "object",
+  "additionalProperties": false,
+  "properties": {
+    "backup_hour": {"type": ["integer", "null"], "minimum": 0, "maximum": 23},
+    "ip_filter": {"type": "array", "items": {"type": "string"}},
+    "pg_version": {"type": "string", "enum": ["12", "13"]},
+    "pglookout": {
+      "type": "object",
+      "properties": {
+        "max_failover_replication_time_lag": {"type": "integer"}
+      }
+    }
+  }
+}`
+
+	tests := []struct {
+		name    string
+		v       string
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			v:    `{"backup_hour": 1, "ip_filter": ["1.2.3.4/32"], "pg_version": "13"}`,
+		},
+		{
+			name: "ok null",
+			v:    `{"backup_hour": null}`,
+		},
+		{
+			name: "ok nested unknown property",
+			v:    `{"pglookout": {"lolnope": true}}`,
+		},
+		{
+			name:    "unknown property",
+			v:       `{"backup_hours": 1}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid type",
+			v:       `{"backup_hour": "1"}`,
+			wantErr: true,
+		},
+		{
+			name:    "not an integer",
+			v:       `{"backup_hour": 1.5}`,
+			wantErr: true,
+		},
+		{
+			name:    "greater than maximum",
+			v:       `{"backup_hour": 24}`,
+			wantErr: true,
+		},
+		{
+			name:    "not in enum",
+			v:       `{"pg_version": "9"}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid array item",
+			v:       `{"ip_filter": [42]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid nested type",
+			v:       `{"pglookout": {"max_failover_replication_time_lag": "60"}}`,
+			wantErr: true,
+		},
+	}
+
+	var s map[string]interface{}
+	if err := json.Unmarshal([]byte(testSchema), &s); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v interface{}
+			if err := json.Unmarshal([]byte(tt.v), &v); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := validateJSONSchema(s, v, "test"); (err != nil) != tt.wantErr {
+				t.Errorf("validateJSONSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,12 +1,37 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+
 	"github.com/exoscale/terraform-provider-exoscale/exoscale"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 )
 
 func main() {
+	doctor := flag.Bool("doctor", false,
+		"Check the provider environment (credentials, API endpoint, clock skew, quota) and print a JSON report")
+	flag.Parse()
+
+	if *doctor {
+		report := exoscale.Doctor(context.Background())
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil || !report.OK {
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: exoscale.Provider,
 	})
+
+	// Revoke the API keys minted via the "scoped_key" provider setting, if any
+	// (best effort, the process might be killed before it completes).
+	exoscale.RevokeScopedKeys()
 }